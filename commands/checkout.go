@@ -3,9 +3,11 @@ package commands
 import (
 	"fmt"
 	"regexp"
+	"strconv"
 
 	"github.com/github/hub/v2/git"
 	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/ui"
 	"github.com/github/hub/v2/utils"
 )
 
@@ -15,6 +17,10 @@ var cmdCheckout = &Command{
 	Usage:        "checkout <PULLREQ-URL> [<BRANCH>]",
 	Long: `Check out the head of a pull request as a local branch.
 
+If the local branch already exists but has diverged from the head of the pull
+request, e.g. because the pull request was force-pushed to, the new head is
+checked out in a new "<BRANCH>-v2" branch instead.
+
 ## Examples:
 		$ hub checkout https://github.com/jingweno/gh/pull/73
 		> git fetch origin pull/73/head:jingweno-feature
@@ -64,7 +70,7 @@ func checkout(command *Command, args *Args) {
 	pullRequest, err := gh.PullRequest(url.Project, id)
 	utils.Check(err)
 
-	newArgs, err := transformCheckoutArgs(args, pullRequest, newBranchName)
+	newArgs, err := transformCheckoutArgs(args, pullRequest, newBranchName, "", false)
 	utils.Check(err)
 
 	if idx := args.IndexOfParam(newBranchName); idx >= 0 {
@@ -73,12 +79,21 @@ func checkout(command *Command, args *Args) {
 	replaceCheckoutParam(args, checkoutURL, newArgs...)
 }
 
-func transformCheckoutArgs(args *Args, pullRequest *github.PullRequest, newBranchName string) (newArgs []string, err error) {
+// transformCheckoutArgs sets up fetching and tracking configuration for
+// checking out a pull request. When worktreePath is given, the branch gets
+// updated in that working tree instead of the current one. An existing local
+// branch that has diverged from the pull request is reset if force is set.
+func transformCheckoutArgs(args *Args, pullRequest *github.PullRequest, newBranchName, worktreePath string, force bool) (newArgs []string, err error) {
 	repo, err := github.LocalRepo()
 	if err != nil {
 		return
 	}
 
+	gitMerge := []string{"git", "merge", "--ff-only"}
+	if worktreePath != "" {
+		gitMerge = []string{"git", "-C", worktreePath, "merge", "--ff-only"}
+	}
+
 	baseRemote, err := repo.RemoteForRepo(pullRequest.Base.Repo)
 	if err != nil {
 		return
@@ -97,15 +112,31 @@ func transformCheckoutArgs(args *Args, pullRequest *github.PullRequest, newBranc
 		}
 		remoteBranch := fmt.Sprintf("%s/%s", headRemote.Name, pullRequest.Head.Ref)
 		refSpec := fmt.Sprintf("+refs/heads/%s:refs/remotes/%s", pullRequest.Head.Ref, remoteBranch)
-		if git.HasFile("refs", "heads", newBranchName) {
+		existingBranch := git.HasFile("refs", "heads", newBranchName)
+		compareHead := existingBranch && !args.Noop
+		if err = fetchCheckoutHead(args, compareHead, headRemote.Name, refSpec); err != nil {
+			return
+		}
+
+		var divergedArgs []string
+		if compareHead {
+			if divergedArgs, err = divergedCheckoutArgs(pullRequest, newBranchName, "refs/remotes/"+remoteBranch, force); err != nil {
+				return
+			}
+		}
+
+		if divergedArgs != nil {
+			newArgs = divergedArgs
+		} else if existingBranch {
 			newArgs = append(newArgs, newBranchName)
-			args.After("git", "merge", "--ff-only", fmt.Sprintf("refs/remotes/%s", remoteBranch))
+			args.After(append(gitMerge, fmt.Sprintf("refs/remotes/%s", remoteBranch))...)
 		} else {
 			newArgs = append(newArgs, "-b", newBranchName, "--no-track", remoteBranch)
-			args.After("git", "config", fmt.Sprintf("branch.%s.remote", newBranchName), headRemote.Name)
-			args.After("git", "config", fmt.Sprintf("branch.%s.merge", newBranchName), "refs/heads/"+pullRequest.Head.Ref)
 		}
-		args.Before("git", "fetch", headRemote.Name, refSpec)
+		if newArgs[0] == "-b" {
+			args.After("git", "config", fmt.Sprintf("branch.%s.remote", newArgs[1]), headRemote.Name)
+			args.After("git", "config", fmt.Sprintf("branch.%s.merge", newArgs[1]), "refs/heads/"+pullRequest.Head.Ref)
+		}
 	} else {
 		if newBranchName == "" {
 			newBranchName = pullRequest.Head.Ref
@@ -119,11 +150,26 @@ func transformCheckoutArgs(args *Args, pullRequest *github.PullRequest, newBranc
 		isCurrentBranch := errB == nil && b.ShortName() == newBranchName
 
 		ref := fmt.Sprintf("refs/pull/%d/head", pullRequest.Number)
-		if isCurrentBranch {
-			args.Before("git", "fetch", baseRemote.Name, ref)
-			args.After("git", "merge", "--ff-only", "FETCH_HEAD")
-		} else {
-			args.Before("git", "fetch", baseRemote.Name, fmt.Sprintf("%s:%s", ref, newBranchName))
+		compareHead := git.HasFile("refs", "heads", newBranchName) && !args.Noop
+		fetchRef := ref
+		if !compareHead && !isCurrentBranch {
+			fetchRef = fmt.Sprintf("%s:%s", ref, newBranchName)
+		}
+		if err = fetchCheckoutHead(args, compareHead, baseRemote.Name, fetchRef); err != nil {
+			return
+		}
+
+		var divergedArgs []string
+		if compareHead {
+			if divergedArgs, err = divergedCheckoutArgs(pullRequest, newBranchName, "FETCH_HEAD", force); err != nil {
+				return
+			}
+		}
+		if divergedArgs != nil {
+			newArgs = divergedArgs
+			newBranchName = divergedArgs[1]
+		} else if compareHead || isCurrentBranch {
+			args.After(append(gitMerge, "FETCH_HEAD")...)
 		}
 
 		remote := baseRemote.Name
@@ -147,6 +193,82 @@ func transformCheckoutArgs(args *Args, pullRequest *github.PullRequest, newBranc
 	return
 }
 
+// fetchCheckoutHead fetches the head of a pull request. When an existing local
+// branch is going to be compared to it, the fetch has to happen right away;
+// otherwise it runs just before the checkout.
+func fetchCheckoutHead(args *Args, now bool, remote, refSpec string) error {
+	if now {
+		return git.Spawn("fetch", remote, refSpec)
+	}
+	args.Before("git", "fetch", remote, refSpec)
+	return nil
+}
+
+// divergedCheckoutArgs checks whether an existing local branch can be
+// fast-forwarded to the freshly fetched head of a pull request. If not, it
+// prints how the two differ and returns the arguments to `git checkout` that
+// either reset the branch to the new head when force is set, or start a new
+// "-v2" branch from it.
+func divergedCheckoutArgs(pullRequest *github.PullRequest, branch, newHead string, force bool) ([]string, error) {
+	r, err := git.NewRange("refs/heads/"+branch, newHead)
+	if err != nil {
+		return nil, err
+	}
+	if r.IsIdentical() || r.IsAncestor() {
+		return nil, nil
+	}
+
+	localCommits, err := git.RefList(r.B, r.A)
+	if err != nil {
+		return nil, err
+	}
+	remoteCommits, err := git.RefList(r.A, r.B)
+	if err != nil {
+		return nil, err
+	}
+
+	ui.Errorf("Branch '%s' has diverged from the head of pull request #%d:\n", branch, pullRequest.Number)
+	for _, commits := range []struct {
+		prefix string
+		shas   []string
+	}{{"-", localCommits}, {"+", remoteCommits}} {
+		for _, sha := range commits.shas {
+			message, err := git.Show(sha)
+			if err != nil {
+				return nil, err
+			}
+			subject, _ := github.SplitTitleBody(message)
+			ui.Errorf("  %s %s %s\n", commits.prefix, sha[:7], subject)
+		}
+	}
+
+	if force {
+		ui.Errorf("Resetting '%s' to %s\n", branch, r.B[:7])
+		return []string{"-B", branch, r.B}, nil
+	}
+
+	newBranch := nextBranchVersion(branch)
+	ui.Errorf("Checking out the new head as '%s' (use `hub pr checkout --force` to reset '%s' instead)\n", newBranch, branch)
+	return []string{"-b", newBranch, "--no-track", r.B}, nil
+}
+
+// nextBranchVersion turns "feature" into "feature-v2", or "feature-v2" into
+// "feature-v3", skipping over names of branches that already exist
+func nextBranchVersion(branch string) string {
+	name, version := branch, 1
+	if m := regexp.MustCompile(`^(.+)-v(\d+)$`).FindStringSubmatch(branch); m != nil {
+		name = m[1]
+		version, _ = strconv.Atoi(m[2])
+	}
+	for {
+		version++
+		candidate := fmt.Sprintf("%s-v%d", name, version)
+		if !git.HasFile("refs", "heads", candidate) {
+			return candidate
+		}
+	}
+}
+
 func sanitizeCheckoutFlags(args *Args) error {
 	if i := args.IndexOfParam("-b"); i != -1 {
 		return fmt.Errorf("Unsupported flag -b when checking out pull request")
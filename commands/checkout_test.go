@@ -0,0 +1,21 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/github/hub/v2/fixtures"
+	"github.com/github/hub/v2/git"
+	"github.com/github/hub/v2/internal/assert"
+)
+
+func TestNextBranchVersion(t *testing.T) {
+	repo := fixtures.SetupTestRepo()
+	defer repo.TearDown()
+
+	assert.Equal(t, nil, git.Spawn("branch", "fixes-v2"))
+
+	assert.Equal(t, "fixes-v3", nextBranchVersion("fixes"))
+	assert.Equal(t, "fixes-v3", nextBranchVersion("fixes-v2"))
+	assert.Equal(t, "fixes-v8", nextBranchVersion("fixes-v7"))
+	assert.Equal(t, "feature-v2", nextBranchVersion("feature"))
+}
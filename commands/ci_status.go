@@ -103,26 +103,7 @@ func ciStatus(cmd *Command, args *Args) {
 		response, err := gh.FetchCIStatus(project, sha)
 		utils.Check(err)
 
-		state := ""
-		if len(response.Statuses) > 0 {
-			for _, status := range response.Statuses {
-				if checkSeverity(status.State) > checkSeverity(state) {
-					state = status.State
-				}
-			}
-		}
-
-		var exitCode int
-		switch state {
-		case "success", "neutral":
-			exitCode = 0
-		case "failure", "error", "action_required", "cancelled", "timed_out":
-			exitCode = 1
-		case "pending":
-			exitCode = 2
-		default:
-			exitCode = 3
-		}
+		state := ciStatusState(response.Statuses)
 
 		verbose := args.Flag.Bool("--verbose") || args.Flag.HasReceived("--format")
 		if verbose && len(response.Statuses) > 0 {
@@ -136,7 +117,30 @@ func ciStatus(cmd *Command, args *Args) {
 			}
 		}
 
-		os.Exit(exitCode)
+		os.Exit(ciStatusExitCode(state))
+	}
+}
+
+func ciStatusState(statuses []github.CIStatus) string {
+	state := ""
+	for _, status := range statuses {
+		if checkSeverity(status.State) > checkSeverity(state) {
+			state = status.State
+		}
+	}
+	return state
+}
+
+func ciStatusExitCode(state string) int {
+	switch state {
+	case "success", "neutral":
+		return 0
+	case "failure", "error", "action_required", "cancelled", "timed_out":
+		return 1
+	case "pending":
+		return 2
+	default:
+		return 3
 	}
 }
 
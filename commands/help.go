@@ -222,6 +222,8 @@ These GitHub commands are provided by hub:
    fork           Make a fork of a remote repository on GitHub and add as remote
    gist           Make a gist
    issue          List or create GitHub issues
+   label          Manage labels of a GitHub repository
+   milestone      Manage GitHub milestones
    pr             Manage GitHub pull requests
    pull-request   Open a pull request on GitHub
    release        List or create GitHub releases
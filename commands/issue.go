@@ -18,9 +18,15 @@ var (
 		Run: listIssues,
 		Usage: `
 issue [-a <ASSIGNEE>] [-c <CREATOR>] [-@ <USER>] [-s <STATE>] [-f <FORMAT>] [-M <MILESTONE>] [-l <LABELS>] [-d <DATE>] [-o <SORT_KEY> [-^]] [-L <LIMIT>]
+issue --search <QUERY> [-s <STATE>] [-f <FORMAT>] [-o <SORT_KEY> [-^]] [-L <LIMIT>]
 issue show [-f <FORMAT>] <NUMBER>
-issue create [-oc] [-m <MESSAGE>|-F <FILE>] [--edit] [-a <USERS>] [-M <MILESTONE>] [-l <LABELS>]
+issue create [-oc] [-m <MESSAGE>|-F <FILE>] [--edit] [--template <NAME>] [-a <USERS>] [-M <MILESTONE>] [-l <LABELS>]
 issue update <NUMBER> [-m <MESSAGE>|-F <FILE>] [--edit] [-a <USERS>] [-M <MILESTONE>] [-l <LABELS>] [-s <STATE>]
+issue update (<NUMBER>... | --query <SEARCH>) [-a <USERS>] [-M <MILESTONE>] [-l <LABELS>] [-s <STATE>]
+issue comment <NUMBER> [-m <MESSAGE>|-F <FILE>] [--edit] [--edit-last]
+issue comment <NUMBER> --delete <ID>
+issue close <NUMBER> [--reason <REASON>] [--duplicate-of <NUMBER>] [-m <MESSAGE>|-F <FILE>]
+issue reopen <NUMBER> [-m <MESSAGE>|-F <FILE>]
 issue labels [--color]
 issue transfer <NUMBER> <REPO>
 `,
@@ -28,7 +34,8 @@ issue transfer <NUMBER> <REPO>
 
 ## Commands:
 
-With no arguments, show a list of open issues.
+With no arguments, show a list of open issues. With ''--search'', the list is
+retrieved using the GitHub search API instead.
 
 	* _show_:
 		Show an existing issue specified by <NUMBER>.
@@ -40,6 +47,25 @@ With no arguments, show a list of open issues.
 		Update fields of an existing issue specified by <NUMBER>. Use ''--edit''
 		to edit the title and message interactively in the text editor.
 
+		When multiple issue numbers are given, or the issues are selected with
+		''--query'', the same changes are applied to all of them and the outcome
+		is reported for each issue. Use ''hub --noop'' to preview which issues
+		would be updated.
+
+	* _comment_:
+		Post a comment on the issue specified by <NUMBER>. Unless ''--message''
+		or ''--file'' were supplied, a text editor will open to author the comment
+		in. Use ''--edit-last'' to change your most recent comment on the issue
+		instead, or ''--delete'' to delete a comment.
+
+	* _close_:
+		Close the issue specified by <NUMBER>. Leave a closing comment with
+		''--message'' or ''--file''.
+
+	* _reopen_:
+		Reopen the closed issue specified by <NUMBER>. Leave a comment with
+		''--message'' or ''--file''.
+
 	* _labels_:
 		List the labels available in this repository.
 
@@ -132,6 +158,9 @@ With no arguments, show a list of open issues.
 		When neither ''--message'' nor ''--file'' were supplied to ''issue create'', a
 		text editor will open to author the title and description in.
 
+		When commenting, closing, or reopening, the whole <MESSAGE> is used as the
+		comment body in Markdown format.
+
 	-F, --file <FILE>
 		Read the issue title and description from <FILE>. Pass "-" to read from
 		standard input instead. See ''--message'' for the formatting rules.
@@ -140,6 +169,27 @@ With no arguments, show a list of open issues.
 		Open the issue title and description in a text editor before submitting.
 		This can be used in combination with ''--message'' or ''--file''.
 
+	--template <NAME>
+		Prefill the text editor with the named template from the "ISSUE_TEMPLATE/"
+		directory. YAML issue forms are turned into a skeleton of their fields.
+		When that directory has templates and no <NAME> was given, hub offers to
+		choose one on a terminal.
+
+	--reason <REASON>
+		The reason for closing an issue: "completed" (default) or "not_planned".
+
+	--duplicate-of <NUMBER>
+		Close an issue as a duplicate of issue <NUMBER>. A comment that marks it as
+		such is posted, and the reason defaults to "not_planned".
+
+	--edit-last
+		Edit the most recent comment of the current user instead of posting a new
+		one. Without ''--message'' or ''--file'', the existing comment is opened in
+		a text editor.
+
+	--delete <ID>
+		Delete the comment with the given <ID>.
+
 	-o, --browse
 		Open the new issue in a web browser.
 
@@ -172,6 +222,16 @@ With no arguments, show a list of open issues.
 	--include-pulls
 		Include pull requests as well as issues.
 
+	--query <SEARCH>
+		Update all issues and pull requests in the current repository that match
+		the <SEARCH> query, e.g. "is:open label:needs-triage".
+
+	--search <QUERY>
+		Display only issues matching the search <QUERY>, e.g.
+		"no:assignee -label:wontfix". See "Searching issues and pull requests" in
+		GitHub Help for the available qualifiers. Other filtering options are
+		translated to search qualifiers when combined with ''--search''.
+
 	--color
 		Enable colored output for labels list.
 
@@ -193,6 +253,7 @@ hub-pr(1), hub(1)
 		--include-pulls
 		-L, --limit N
 		--color
+		--search QUERY
 `,
 	}
 
@@ -208,6 +269,7 @@ hub-pr(1), hub(1)
 		-o, --browse
 		-c, --copy
 		-e, --edit
+		--template NAME
 `,
 	}
 
@@ -233,6 +295,38 @@ hub-pr(1), hub(1)
 		Run: transferIssue,
 	}
 
+	cmdCommentIssue = &Command{
+		Key: "comment",
+		Run: commentIssue,
+		KnownFlags: `
+		-m, --message MSG
+		-F, --file FILE
+		-e, --edit
+		--edit-last
+		--delete ID
+`,
+	}
+
+	cmdCloseIssue = &Command{
+		Key: "close",
+		Run: closeIssue,
+		KnownFlags: `
+		-m, --message MSG
+		-F, --file FILE
+		--reason REASON
+		--duplicate-of NUMBER
+`,
+	}
+
+	cmdReopenIssue = &Command{
+		Key: "reopen",
+		Run: reopenIssue,
+		KnownFlags: `
+		-m, --message MSG
+		-F, --file FILE
+`,
+	}
+
 	cmdUpdate = &Command{
 		Key: "update",
 		Run: updateIssue,
@@ -244,6 +338,7 @@ hub-pr(1), hub(1)
 		-a, --assign USER
 		-e, --edit
 		-s, --state STATE
+		--query SEARCH
 `,
 	}
 )
@@ -254,6 +349,9 @@ func init() {
 	cmdIssue.Use(cmdLabel)
 	cmdIssue.Use(cmdTransfer)
 	cmdIssue.Use(cmdUpdate)
+	cmdIssue.Use(cmdCommentIssue)
+	cmdIssue.Use(cmdCloseIssue)
+	cmdIssue.Use(cmdReopenIssue)
 	CmdRunner.Use(cmdIssue)
 }
 
@@ -276,12 +374,12 @@ func listIssues(cmd *Command, args *Args) {
 		if args.Flag.HasReceived("--assignee") {
 			filters["assignee"] = args.Flag.Value("--assignee")
 		}
-		if args.Flag.HasReceived("--milestone") {
+		if args.Flag.HasReceived("--milestone") && !args.Flag.HasReceived("--search") {
 			milestoneValue := args.Flag.Value("--milestone")
 			if milestoneValue == "none" {
 				filters["milestone"] = milestoneValue
 			} else {
-				milestoneNumber, err := milestoneValueToNumber(milestoneValue, gh, project)
+				milestoneNumber, err := milestoneValueToNumber(milestoneValue, gh, project, "open")
 				utils.Check(err)
 				if milestoneNumber > 0 {
 					filters["milestone"] = milestoneNumber
@@ -324,9 +422,26 @@ func listIssues(cmd *Command, args *Args) {
 			flagIssueFormat = args.Flag.Value("--format")
 		}
 
-		issues, err := gh.FetchIssues(project, filters, flagIssueLimit, func(issue *github.Issue) bool {
+		var issues []github.Issue
+		filterPulls := func(issue *github.Issue) bool {
 			return issue.PullRequest == nil || flagIssueIncludePulls
-		})
+		}
+		if args.Flag.HasReceived("--search") {
+			searchParams := map[string]interface{}{
+				"sort": "created",
+			}
+			if sort, ok := filters["sort"]; ok {
+				searchParams["sort"] = sort
+			}
+			if args.Flag.HasReceived("--sort") || args.Flag.Bool("--sort-ascending") {
+				searchParams["order"] = filters["direction"]
+			}
+			query, err := issueSearchQuery(project, args, gh)
+			utils.Check(err)
+			issues, err = gh.SearchIssues(query, searchParams, flagIssueLimit, filterPulls)
+		} else {
+			issues, err = gh.FetchIssues(project, filters, flagIssueLimit, filterPulls)
+		}
 		utils.Check(err)
 
 		maxNumWidth := 0
@@ -345,6 +460,64 @@ func listIssues(cmd *Command, args *Args) {
 	args.NoForward()
 }
 
+// issueSearchQuery combines the value of `--search` with qualifiers that are
+// equivalent to the other filtering options
+func issueSearchQuery(project *github.Project, args *Args, client *github.Client) (string, error) {
+	qualifiers := []string{
+		fmt.Sprintf("repo:%s/%s", project.Owner, project.Name),
+	}
+	if !args.Flag.Bool("--include-pulls") {
+		qualifiers = append(qualifiers, "is:issue")
+	}
+
+	state := "open"
+	if args.Flag.HasReceived("--state") {
+		state = args.Flag.Value("--state")
+	}
+	if state != "all" {
+		qualifiers = append(qualifiers, "is:"+state)
+	}
+
+	quote := func(value string) string {
+		if strings.ContainsAny(value, " \t") {
+			return fmt.Sprintf("%q", value)
+		}
+		return value
+	}
+
+	for _, filter := range [][2]string{
+		{"--assignee", "assignee"},
+		{"--creator", "author"},
+		{"--mentioned", "mentions"},
+	} {
+		if args.Flag.HasReceived(filter[0]) {
+			qualifiers = append(qualifiers, fmt.Sprintf("%s:%s", filter[1], args.Flag.Value(filter[0])))
+		}
+	}
+	if args.Flag.HasReceived("--milestone") {
+		if milestone := args.Flag.Value("--milestone"); milestone == "none" {
+			qualifiers = append(qualifiers, "no:milestone")
+		} else {
+			// search only understands milestone titles
+			if _, err := strconv.Atoi(milestone); err == nil {
+				if milestone, err = milestoneValueToTitle(milestone, client, project); err != nil {
+					return "", err
+				}
+			}
+			qualifiers = append(qualifiers, "milestone:"+quote(milestone))
+		}
+	}
+	for _, label := range commaSeparated(args.Flag.AllValues("--labels")) {
+		qualifiers = append(qualifiers, "label:"+quote(label))
+	}
+	if args.Flag.HasReceived("--since") {
+		qualifiers = append(qualifiers, "updated:>="+args.Flag.Value("--since"))
+	}
+
+	qualifiers = append(qualifiers, args.Flag.Value("--search"))
+	return strings.Join(qualifiers, " "), nil
+}
+
 func formatIssuePlaceholders(issue github.Issue, colorize bool) map[string]string {
 	var stateColorSwitch string
 	if colorize {
@@ -554,6 +727,10 @@ func showIssue(cmd *Command, args *Args) {
 }
 
 func createIssue(cmd *Command, args *Args) {
+	if args.Flag.HasReceived("--template") && hasField(args, "--message", "--file") {
+		utils.Check(cmd.UsageError("cannot use --template together with --message or --file"))
+	}
+
 	localRepo, err := github.LocalRepo()
 	utils.Check(err)
 
@@ -586,7 +763,7 @@ text is the title and the rest is the description.`, project))
 
 		workdir, _ := git.WorkdirName()
 		if workdir != "" {
-			template, err := github.ReadTemplate(github.IssueTemplate, workdir)
+			template, err := readTemplate(github.IssueTemplate, workdir, args)
 			utils.Check(err)
 			if template != "" {
 				messageBuilder.Message = template
@@ -629,13 +806,20 @@ text is the title and the rest is the description.`, project))
 }
 
 func updateIssue(cmd *Command, args *Args) {
-	issueNumber := 0
-	if args.ParamsSize() > 0 {
-		issueNumber, _ = strconv.Atoi(args.GetParam(0))
+	issueNumbers := []int{}
+	for i := 0; i < args.ParamsSize(); i++ {
+		issueNumber, _ := strconv.Atoi(args.GetParam(i))
+		if issueNumber == 0 {
+			utils.Check(cmd.UsageError(fmt.Sprintf("invalid issue number: '%s'", args.GetParam(i))))
+		}
+		issueNumbers = append(issueNumbers, issueNumber)
 	}
-	if issueNumber == 0 {
+	if len(issueNumbers) == 0 && !args.Flag.HasReceived("--query") {
 		utils.Check(cmd.UsageError(""))
 	}
+	if len(issueNumbers) > 0 && args.Flag.HasReceived("--query") {
+		utils.Check(cmd.UsageError("cannot use --query together with issue numbers"))
+	}
 	if !hasField(args, "--message", "--file", "--labels", "--milestone", "--assign", "--state", "--edit") {
 		utils.Check(cmd.UsageError("please specify fields to update"))
 	}
@@ -648,6 +832,15 @@ func updateIssue(cmd *Command, args *Args) {
 
 	gh := github.NewClient(project.Host)
 
+	if len(issueNumbers) > 1 || args.Flag.HasReceived("--query") {
+		if hasField(args, "--message", "--file", "--edit") {
+			utils.Check(cmd.UsageError("the title and description can only be changed for one issue at a time"))
+		}
+		updateIssues(args, gh, project, issueNumbers)
+		return
+	}
+	issueNumber := issueNumbers[0]
+
 	params := map[string]interface{}{}
 	setLabelsFromArgs(params, args)
 	setAssigneesFromArgs(params, args)
@@ -701,6 +894,263 @@ text is the title and the rest is the description.`, issueNumber, project))
 	}
 }
 
+// maximum number of issues that get updated at the same time
+const issueUpdateConcurrency = 4
+
+// updateIssues applies the same changes to many issues concurrently and
+// reports the outcome for each of them
+func updateIssues(args *Args, gh *github.Client, project *github.Project, issueNumbers []int) {
+	if args.Flag.HasReceived("--query") {
+		query := fmt.Sprintf("repo:%s/%s %s", project.Owner, project.Name, args.Flag.Value("--query"))
+		issues, err := gh.SearchIssues(query, nil, 0, nil)
+		utils.Check(err)
+		for _, issue := range issues {
+			issueNumbers = append(issueNumbers, issue.Number)
+		}
+		if len(issueNumbers) == 0 {
+			utils.Check(fmt.Errorf("Error: no issues found matching '%s'", args.Flag.Value("--query")))
+		}
+	}
+
+	params := map[string]interface{}{}
+	setLabelsFromArgs(params, args)
+	setAssigneesFromArgs(params, args)
+	setMilestoneFromArgs(params, args, gh, project)
+	if args.Flag.HasReceived("--state") {
+		params["state"] = args.Flag.Value("--state")
+	}
+
+	args.NoForward()
+	if args.Noop {
+		for _, issueNumber := range issueNumbers {
+			ui.Printf("Would update issue #%d for %s\n", issueNumber, project)
+		}
+		return
+	}
+
+	queue := make(chan int, len(issueNumbers))
+	for i := range issueNumbers {
+		queue <- i
+	}
+	close(queue)
+
+	type updateResult struct {
+		index int
+		err   error
+	}
+	done := make(chan updateResult)
+	for w := 0; w < issueUpdateConcurrency; w++ {
+		go func() {
+			for i := range queue {
+				done <- updateResult{i, gh.UpdateIssue(project, issueNumbers[i], params)}
+			}
+		}()
+	}
+
+	results := make([]error, len(issueNumbers))
+	for range issueNumbers {
+		result := <-done
+		results[result.index] = result.err
+	}
+
+	failed := 0
+	for i, err := range results {
+		if err != nil {
+			failed++
+			ui.Errorf("#%d failed: %s\n", issueNumbers[i], err)
+		} else {
+			ui.Printf("#%d updated\n", issueNumbers[i])
+		}
+	}
+	if failed > 0 {
+		utils.Check(fmt.Errorf("Error: failed to update %d of %d issues", failed, len(issueNumbers)))
+	}
+}
+
+func closeIssue(cmd *Command, args *Args) {
+	setIssueState(cmd, args, "closed")
+}
+
+func reopenIssue(cmd *Command, args *Args) {
+	setIssueState(cmd, args, "open")
+}
+
+func setIssueState(cmd *Command, args *Args, state string) {
+	issueNumber := 0
+	if args.ParamsSize() > 0 {
+		issueNumber, _ = strconv.Atoi(args.GetParam(0))
+	}
+	if issueNumber == 0 {
+		utils.Check(cmd.UsageError(""))
+	}
+
+	params := map[string]interface{}{
+		"state": state,
+	}
+	comments := []string{}
+
+	if state == "closed" {
+		reason := "completed"
+		if args.Flag.HasReceived("--duplicate-of") {
+			duplicateOf, err := strconv.Atoi(strings.TrimPrefix(args.Flag.Value("--duplicate-of"), "#"))
+			if err != nil || duplicateOf == issueNumber {
+				utils.Check(cmd.UsageError(fmt.Sprintf("invalid issue number for --duplicate-of: '%s'", args.Flag.Value("--duplicate-of"))))
+			}
+			// GitHub marks the issue as a duplicate when a comment starts like this
+			comments = append(comments, fmt.Sprintf("Duplicate of #%d", duplicateOf))
+			reason = "not_planned"
+		}
+		if args.Flag.HasReceived("--reason") {
+			reason = strings.Replace(strings.ToLower(args.Flag.Value("--reason")), "-", "_", -1)
+			if reason != "completed" && reason != "not_planned" {
+				utils.Check(cmd.UsageError(fmt.Sprintf("invalid reason: '%s'", args.Flag.Value("--reason"))))
+			}
+		}
+		params["state_reason"] = reason
+	} else {
+		params["state_reason"] = "reopened"
+	}
+
+	if msgs := args.Flag.AllValues("--message"); len(msgs) > 0 {
+		comments = append(comments, strings.Join(msgs, "\n\n"))
+	} else if args.Flag.HasReceived("--file") {
+		content, err := msgFromFile(args.Flag.Value("--file"))
+		utils.Check(err)
+		comments = append(comments, strings.TrimSpace(content))
+	}
+	comment := strings.TrimSpace(strings.Join(comments, "\n\n"))
+
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	args.NoForward()
+	if args.Noop {
+		action := "close"
+		if state == "open" {
+			action = "reopen"
+		}
+		ui.Printf("Would %s issue #%d for %s\n", action, issueNumber, project)
+		return
+	}
+
+	gh := github.NewClient(project.Host)
+	if comment != "" {
+		_, err = gh.CreateIssueComment(project, issueNumber, comment)
+		utils.Check(err)
+	}
+
+	err = gh.UpdateIssue(project, issueNumber, params)
+	utils.Check(err)
+}
+
+func commentIssue(cmd *Command, args *Args) {
+	issueNumber := 0
+	if args.ParamsSize() > 0 {
+		issueNumber, _ = strconv.Atoi(args.GetParam(0))
+	}
+	if issueNumber == 0 {
+		utils.Check(cmd.UsageError(""))
+	}
+
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	gh := github.NewClient(project.Host)
+	postComment(cmd, args, gh, project, issueNumber)
+}
+
+// postComment creates, edits, or deletes a comment on an issue or pull request
+func postComment(cmd *Command, args *Args, gh *github.Client, project *github.Project, number int) {
+	args.NoForward()
+
+	if args.Flag.HasReceived("--delete") {
+		commentID, err := strconv.Atoi(args.Flag.Value("--delete"))
+		if err != nil {
+			utils.Check(cmd.UsageError(fmt.Sprintf("invalid comment ID: '%s'", args.Flag.Value("--delete"))))
+		}
+		if args.Noop {
+			ui.Printf("Would delete comment %d for %s\n", commentID, project)
+			return
+		}
+		err = gh.DeleteIssueComment(project, commentID)
+		utils.Check(err)
+		return
+	}
+
+	var lastComment *github.Comment
+	if args.Flag.Bool("--edit-last") {
+		comments, err := gh.FetchComments(project, strconv.Itoa(number))
+		utils.Check(err)
+		for i := len(comments) - 1; i >= 0; i-- {
+			if comments[i].User != nil && strings.EqualFold(comments[i].User.Login, gh.Host.User) {
+				lastComment = &comments[i]
+				break
+			}
+		}
+		if lastComment == nil {
+			utils.Check(fmt.Errorf("Error: no comments by %s found on #%d", gh.Host.User, number))
+		}
+	}
+
+	messageBuilder := &github.MessageBuilder{
+		Filename: "ISSUE_COMMENT_EDITMSG",
+		Title:    "comment",
+	}
+
+	messageBuilder.AddCommentedSection(fmt.Sprintf(`Commenting on #%d for %s
+
+Write a message for this comment in Markdown format.`, number, project))
+
+	flagCommentEdit := args.Flag.Bool("--edit")
+	flagCommentMessage := args.Flag.AllValues("--message")
+	if len(flagCommentMessage) > 0 {
+		messageBuilder.Message = strings.Join(flagCommentMessage, "\n\n")
+		messageBuilder.Edit = flagCommentEdit
+	} else if args.Flag.HasReceived("--file") {
+		var err error
+		messageBuilder.Message, err = msgFromFile(args.Flag.Value("--file"))
+		utils.Check(err)
+		messageBuilder.Edit = flagCommentEdit
+	} else {
+		if lastComment != nil {
+			messageBuilder.Message = strings.Replace(lastComment.Body, "\r\n", "\n", -1)
+		}
+		messageBuilder.Edit = true
+	}
+
+	body, err := messageBuilder.ExtractBody()
+	utils.Check(err)
+	if body == "" {
+		utils.Check(fmt.Errorf("Aborting due to empty comment"))
+	}
+
+	if args.Noop {
+		if lastComment != nil {
+			ui.Printf("Would update comment %d for %s\n", lastComment.ID, project)
+		} else {
+			ui.Printf("Would comment on #%d for %s\n", number, project)
+		}
+		return
+	}
+
+	var comment *github.Comment
+	if lastComment != nil {
+		comment, err = gh.UpdateIssueComment(project, lastComment.ID, body)
+	} else {
+		comment, err = gh.CreateIssueComment(project, number, body)
+	}
+	utils.Check(err)
+
+	messageBuilder.Cleanup()
+	ui.Println(comment.HTMLURL)
+}
+
 func listLabels(cmd *Command, args *Args) {
 	localRepo, err := github.LocalRepo()
 	utils.Check(err)
@@ -753,7 +1203,7 @@ func setMilestoneFromArgs(params map[string]interface{}, args *Args, gh *github.
 	if !args.Flag.HasReceived("--milestone") {
 		return
 	}
-	milestoneNumber, err := milestoneValueToNumber(args.Flag.Value("--milestone"), gh, project)
+	milestoneNumber, err := milestoneValueToNumber(args.Flag.Value("--milestone"), gh, project, "open")
 	utils.Check(err)
 	if milestoneNumber == 0 {
 		params["milestone"] = nil
@@ -826,26 +1276,51 @@ func pickHighContrastTextColor(color *utils.Color) *utils.Color {
 	return utils.Black
 }
 
-func milestoneValueToNumber(value string, client *github.Client, project *github.Project) (int, error) {
+// milestoneValueToNumber resolves the title of a milestone in the given state
+// ("open", "closed", or "all") to its number. Titles take precedence so that
+// milestones named e.g. "2024" can be found; any other numeric value is taken
+// to be a milestone number.
+func milestoneValueToNumber(value string, client *github.Client, project *github.Project, state string) (int, error) {
 	if value == "" {
 		return 0, nil
 	}
 
+	milestones, err := client.FetchMilestones(project, map[string]interface{}{"state": state})
+	if err != nil {
+		return 0, err
+	}
+	for _, milestone := range milestones {
+		if strings.EqualFold(milestone.Title, value) {
+			return milestone.Number, nil
+		}
+	}
+
 	if milestoneNumber, err := strconv.Atoi(value); err == nil {
 		return milestoneNumber, nil
 	}
 
-	milestones, err := client.FetchMilestones(project)
+	return 0, fmt.Errorf("error: no milestone found with name '%s'", value)
+}
+
+// milestoneValueToTitle resolves a milestone title or number to the title of
+// the milestone, which is what search qualifiers expect
+func milestoneValueToTitle(value string, client *github.Client, project *github.Project) (string, error) {
+	milestones, err := client.FetchMilestones(project, map[string]interface{}{"state": "all"})
 	if err != nil {
-		return 0, err
+		return "", err
 	}
 	for _, milestone := range milestones {
 		if strings.EqualFold(milestone.Title, value) {
-			return milestone.Number, nil
+			return milestone.Title, nil
+		}
+	}
+	for _, milestone := range milestones {
+		if strconv.Itoa(milestone.Number) == value {
+			return milestone.Title, nil
 		}
 	}
 
-	return 0, fmt.Errorf("error: no milestone found with name '%s'", value)
+	return "", fmt.Errorf("error: no milestone found with name '%s'", value)
 }
 
 func transferIssue(cmd *Command, args *Args) {
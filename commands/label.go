@@ -0,0 +1,436 @@
+package commands
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/ui"
+	"github.com/github/hub/v2/utils"
+	"gopkg.in/yaml.v2"
+)
+
+var (
+	cmdLabels = &Command{
+		Run: listLabels,
+		Usage: `
+label [--color]
+label create [-c <COLOR>] [-d <DESCRIPTION>] <NAME>
+label edit [-n <NEW-NAME>] [-c <COLOR>] [-d <DESCRIPTION>] <NAME>
+label delete <NAME>
+label sync -F <FILE> [--prune]
+label clone [--prune] <OWNER>/<REPO>
+`,
+		Long: `Manage labels for the current repository.
+
+## Commands:
+
+With no arguments, shows a list of existing labels.
+
+	* _create_:
+		Create a label named <NAME>. Without ''--label-color'', the label is gray.
+
+	* _edit_:
+		Change the name, color, or description of the label named <NAME>.
+
+	* _delete_:
+		Delete the label named <NAME>. It will be removed from all issues and pull
+		requests that it was applied to.
+
+	* _sync_:
+		Make the labels of the current repository match the ones declared in <FILE>.
+		Labels that do not exist yet are created, and existing labels whose color
+		or description differ are updated. Label names are matched
+		case-insensitively.
+
+		<FILE> is in YAML format and lists the labels with their color and an
+		optional description:
+
+			- name: bug
+			  color: d73a4a
+			  description: Something isn't working
+			- name: enhancement
+			  color: a2eeef
+
+		Use ''hub --noop label sync'' to preview the changes without applying them.
+
+	* _clone_:
+		Copy the labels of the <OWNER>/<REPO> repository to the current repository
+		following the same rules as _sync_.
+
+## Options:
+	-c, --label-color <COLOR>
+		The color of the label as a hexadecimal code, e.g. "d73a4a".
+
+	-d, --description <DESCRIPTION>
+		A short description of the label.
+
+	-n, --name <NEW-NAME>
+		Rename the label to <NEW-NAME>.
+
+	-F, --file <FILE>
+		Read label definitions from <FILE>. Pass "-" to read from standard input
+		instead.
+
+	--prune
+		When syncing or cloning, delete labels that are not declared in the source.
+
+	--color[=<WHEN>]
+		Enable colored output even if stdout is not a terminal. <WHEN> can be one
+		of "always" (default for ''--color''), "never", or "auto" (default).
+
+## Examples:
+		$ hub label create -c d73a4a -d "Something isn't working" bug
+		$ hub label sync --file .github/labels.yml --prune
+		$ hub label clone github/hub
+
+## See also:
+
+hub-issue(1), hub(1)
+`,
+		KnownFlags: `
+		--color
+`,
+	}
+
+	cmdCreateLabel = &Command{
+		Key: "create",
+		Run: createLabel,
+		KnownFlags: `
+		-c, --label-color COLOR
+		-d, --description DESC
+`,
+	}
+
+	cmdEditLabel = &Command{
+		Key: "edit",
+		Run: editLabel,
+		KnownFlags: `
+		-n, --name NAME
+		-c, --label-color COLOR
+		-d, --description DESC
+`,
+	}
+
+	cmdDeleteLabel = &Command{
+		Key: "delete",
+		Run: deleteLabel,
+	}
+
+	cmdSyncLabels = &Command{
+		Key: "sync",
+		Run: syncLabels,
+		KnownFlags: `
+		-F, --file FILE
+		--prune
+`,
+	}
+
+	cmdCloneLabels = &Command{
+		Key: "clone",
+		Run: cloneLabels,
+		KnownFlags: `
+		--prune
+`,
+	}
+)
+
+func init() {
+	cmdLabels.Use(cmdCreateLabel)
+	cmdLabels.Use(cmdEditLabel)
+	cmdLabels.Use(cmdDeleteLabel)
+	cmdLabels.Use(cmdSyncLabels)
+	cmdLabels.Use(cmdCloneLabels)
+	CmdRunner.Use(cmdLabels)
+}
+
+func createLabel(cmd *Command, args *Args) {
+	if args.ParamsSize() != 1 {
+		utils.Check(cmd.UsageError(""))
+	}
+
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	params := map[string]interface{}{
+		"name": args.GetParam(0),
+	}
+	if args.Flag.HasReceived("--label-color") {
+		color, err := normalizeLabelColor(args.Flag.Value("--label-color"))
+		utils.Check(err)
+		params["color"] = color
+	} else {
+		params["color"] = defaultLabelColor
+	}
+	if args.Flag.HasReceived("--description") {
+		params["description"] = args.Flag.Value("--description")
+	}
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would create label '%s' for %s\n", params["name"], project)
+		return
+	}
+
+	gh := github.NewClient(project.Host)
+	label, err := gh.CreateLabel(project, params)
+	utils.Check(err)
+
+	ui.Printf("Created label '%s'\n", label.Name)
+}
+
+func editLabel(cmd *Command, args *Args) {
+	if args.ParamsSize() != 1 {
+		utils.Check(cmd.UsageError(""))
+	}
+	if !hasField(args, "--name", "--label-color", "--description") {
+		utils.Check(cmd.UsageError("please specify fields to update"))
+	}
+
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	name := args.GetParam(0)
+	params := map[string]interface{}{}
+	if args.Flag.HasReceived("--name") {
+		params["new_name"] = args.Flag.Value("--name")
+	}
+	if args.Flag.HasReceived("--label-color") {
+		color, err := normalizeLabelColor(args.Flag.Value("--label-color"))
+		utils.Check(err)
+		params["color"] = color
+	}
+	if args.Flag.HasReceived("--description") {
+		params["description"] = args.Flag.Value("--description")
+	}
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would update label '%s' for %s\n", name, project)
+		return
+	}
+
+	gh := github.NewClient(project.Host)
+	label, err := gh.UpdateLabel(project, name, params)
+	utils.Check(err)
+
+	ui.Printf("Updated label '%s'\n", label.Name)
+}
+
+func deleteLabel(cmd *Command, args *Args) {
+	if args.ParamsSize() != 1 {
+		utils.Check(cmd.UsageError(""))
+	}
+
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	name := args.GetParam(0)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would delete label '%s' for %s\n", name, project)
+		return
+	}
+
+	gh := github.NewClient(project.Host)
+	err = gh.DeleteLabel(project, name)
+	utils.Check(err)
+
+	ui.Printf("Deleted label '%s'\n", name)
+}
+
+func syncLabels(cmd *Command, args *Args) {
+	if !args.Flag.HasReceived("--file") || args.ParamsSize() > 0 {
+		utils.Check(cmd.UsageError(""))
+	}
+
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	filename := args.Flag.Value("--file")
+	content, err := msgFromFile(filename)
+	utils.Check(err)
+
+	declared, err := parseLabelDefinitions([]byte(content))
+	if err != nil {
+		utils.Check(fmt.Errorf("Error reading labels from %s: %s", filename, err))
+	}
+
+	applyLabels(args, project, declared)
+}
+
+func cloneLabels(cmd *Command, args *Args) {
+	if args.ParamsSize() != 1 || !strings.Contains(args.GetParam(0), "/") {
+		utils.Check(cmd.UsageError(""))
+	}
+
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	sourceProject := github.NewProject(args.GetParam(0), "", project.Host)
+	gh := github.NewClient(sourceProject.Host)
+	sourceLabels, err := gh.FetchLabels(sourceProject)
+	utils.Check(err)
+
+	applyLabels(args, project, sourceLabels)
+}
+
+// applyLabels changes the labels of a project so that they match the declared
+// ones, printing each change that was made
+func applyLabels(args *Args, project *github.Project, declared []github.IssueLabel) {
+	args.NoForward()
+
+	gh := github.NewClient(project.Host)
+	existing, err := gh.FetchLabels(project)
+	utils.Check(err)
+
+	changes := diffLabels(existing, declared, args.Flag.Bool("--prune"))
+	if len(changes) == 0 {
+		ui.Printf("Labels for %s are up to date\n", project)
+		return
+	}
+
+	for _, change := range changes {
+		if args.Noop {
+			ui.Printf("Would %s label '%s'\n", change.action, change.label.Name)
+			continue
+		}
+
+		switch change.action {
+		case "create":
+			_, err = gh.CreateLabel(project, map[string]interface{}{
+				"name":        change.label.Name,
+				"color":       change.label.Color,
+				"description": change.label.Description,
+			})
+			utils.Check(err)
+			ui.Printf("Created label '%s'\n", change.label.Name)
+		case "update":
+			_, err = gh.UpdateLabel(project, change.name, map[string]interface{}{
+				"new_name":    change.label.Name,
+				"color":       change.label.Color,
+				"description": change.label.Description,
+			})
+			utils.Check(err)
+			ui.Printf("Updated label '%s'\n", change.label.Name)
+		case "delete":
+			err = gh.DeleteLabel(project, change.name)
+			utils.Check(err)
+			ui.Printf("Deleted label '%s'\n", change.name)
+		}
+	}
+}
+
+type labelChange struct {
+	// one of "create", "update", or "delete"
+	action string
+	// the current name of the label to update or delete
+	name  string
+	label github.IssueLabel
+}
+
+// diffLabels lists the changes needed to turn the existing labels into the
+// declared ones. Labels are matched by name case-insensitively, and existing
+// labels that were not declared are only deleted when prune is set.
+func diffLabels(existing, declared []github.IssueLabel, prune bool) []labelChange {
+	existingByName := map[string]github.IssueLabel{}
+	for _, label := range existing {
+		existingByName[strings.ToLower(label.Name)] = label
+	}
+
+	changes := []labelChange{}
+	seen := map[string]bool{}
+	for _, label := range declared {
+		key := strings.ToLower(label.Name)
+		seen[key] = true
+		current, found := existingByName[key]
+		if !found {
+			changes = append(changes, labelChange{action: "create", name: label.Name, label: label})
+		} else if current.Name != label.Name ||
+			!strings.EqualFold(current.Color, label.Color) ||
+			current.Description != label.Description {
+			changes = append(changes, labelChange{action: "update", name: current.Name, label: label})
+		}
+	}
+
+	if prune {
+		for _, label := range existing {
+			if !seen[strings.ToLower(label.Name)] {
+				changes = append(changes, labelChange{action: "delete", name: label.Name, label: label})
+			}
+		}
+	}
+
+	return changes
+}
+
+// parseLabelDefinitions reads a YAML list of labels and validates that each of
+// them has a unique name and a valid color
+func parseLabelDefinitions(content []byte) ([]github.IssueLabel, error) {
+	definitions := []struct {
+		Name        string `yaml:"name"`
+		Color       string `yaml:"color"`
+		Description string `yaml:"description"`
+	}{}
+	if err := yaml.UnmarshalStrict(content, &definitions); err != nil {
+		return nil, err
+	}
+
+	labels := []github.IssueLabel{}
+	seen := map[string]bool{}
+	for i, definition := range definitions {
+		if definition.Name == "" {
+			return nil, fmt.Errorf("label #%d is missing a name", i+1)
+		}
+		key := strings.ToLower(definition.Name)
+		if seen[key] {
+			return nil, fmt.Errorf("label '%s' is declared more than once", definition.Name)
+		}
+		seen[key] = true
+
+		color, err := normalizeLabelColor(definition.Color)
+		if err != nil {
+			return nil, fmt.Errorf("label '%s': %s", definition.Name, err)
+		}
+
+		labels = append(labels, github.IssueLabel{
+			Name:        definition.Name,
+			Color:       color,
+			Description: definition.Description,
+		})
+	}
+
+	return labels, nil
+}
+
+// the color that GitHub uses for new labels by default
+const defaultLabelColor = "ededed"
+
+var labelColorRegexp = regexp.MustCompile(`^[0-9a-f]{6}$`)
+
+// normalizeLabelColor turns "#D73A4A" into "d73a4a", the format that the API
+// expects
+func normalizeLabelColor(color string) (string, error) {
+	normalized := strings.ToLower(strings.TrimPrefix(color, "#"))
+	if !labelColorRegexp.MatchString(normalized) {
+		return "", fmt.Errorf("invalid label color: '%s'", color)
+	}
+	return normalized, nil
+}
@@ -0,0 +1,71 @@
+package commands
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/internal/assert"
+)
+
+func TestDiffLabels(t *testing.T) {
+	existing := []github.IssueLabel{
+		{Name: "Bug", Color: "d73a4a", Description: "Something isn't working"},
+		{Name: "enhancement", Color: "A2EEEF"},
+		{Name: "question", Color: "d876e3", Description: "Further information is requested"},
+		{Name: "wontfix", Color: "ffffff"},
+	}
+	declared := []github.IssueLabel{
+		{Name: "bug", Color: "d73a4a", Description: "Something isn't working"},
+		{Name: "enhancement", Color: "a2eeef"},
+		{Name: "question", Color: "d876e3", Description: "Needs more information"},
+		{Name: "needs-triage", Color: "ededed"},
+	}
+
+	summarize := func(changes []labelChange) []string {
+		summary := []string{}
+		for _, change := range changes {
+			summary = append(summary, fmt.Sprintf("%s %s -> %s", change.action, change.name, change.label.Name))
+		}
+		return summary
+	}
+
+	assert.Equal(t, []string{
+		"update Bug -> bug",
+		"update question -> question",
+		"create needs-triage -> needs-triage",
+	}, summarize(diffLabels(existing, declared, false)))
+
+	assert.Equal(t, []string{
+		"update Bug -> bug",
+		"update question -> question",
+		"create needs-triage -> needs-triage",
+		"delete wontfix -> wontfix",
+	}, summarize(diffLabels(existing, declared, true)))
+
+	assert.Equal(t, []string{}, summarize(diffLabels(existing, existing, true)))
+}
+
+func TestParseLabelDefinitions(t *testing.T) {
+	labels, err := parseLabelDefinitions([]byte(`
+- name: bug
+  color: "#D73A4A"
+  description: Something isn't working
+- name: enhancement
+  color: a2eeef
+`))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []github.IssueLabel{
+		{Name: "bug", Color: "d73a4a", Description: "Something isn't working"},
+		{Name: "enhancement", Color: "a2eeef"},
+	}, labels)
+
+	_, err = parseLabelDefinitions([]byte("- name: bug\n  color: red\n"))
+	assert.Equal(t, "label 'bug': invalid label color: 'red'", err.Error())
+
+	_, err = parseLabelDefinitions([]byte("- name: bug\n  color: d73a4a\n- name: Bug\n  color: d73a4a\n"))
+	assert.Equal(t, "label 'Bug' is declared more than once", err.Error())
+
+	_, err = parseLabelDefinitions([]byte("- color: d73a4a\n"))
+	assert.Equal(t, "label #1 is missing a name", err.Error())
+}
@@ -0,0 +1,437 @@
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/ui"
+	"github.com/github/hub/v2/utils"
+)
+
+var (
+	cmdMilestone = &Command{
+		Run: listMilestones,
+		Usage: `
+milestone [list] [-s <STATE>] [-f <FORMAT>] [-o <SORT_KEY> [-^]]
+milestone create [--due <DATE>] [-d <DESCRIPTION>] <TITLE>
+milestone edit [-t <TITLE>] [--due <DATE>] [-d <DESCRIPTION>] [-s <STATE>] <MILESTONE>
+milestone close <MILESTONE>
+milestone delete <MILESTONE>
+`,
+		Long: `Manage GitHub milestones for the current repository.
+
+## Commands:
+
+With no arguments, or with _list_, shows a list of open milestones together
+with the number of open and closed issues in each of them.
+
+	* _create_:
+		Create a milestone titled <TITLE> and print its URL.
+
+	* _edit_:
+		Change the title, due date, description, or state of <MILESTONE> and
+		print its URL.
+
+	* _close_:
+		Close <MILESTONE> and print its URL. Reopen it with
+		''milestone edit --state open''.
+
+	* _delete_:
+		Delete <MILESTONE>. Issues and pull requests that were in it are kept,
+		but are no longer assigned to any milestone.
+
+## Options:
+	-s, --state <STATE>
+		Display milestones with state <STATE> (default: "open"). Use "all" to list
+		both open and closed milestones.
+
+		When editing, set the state of the milestone to "open" or "closed".
+
+	-t, --title <TITLE>
+		Rename the milestone to <TITLE>.
+
+	--due <DATE>
+		The due date of the milestone in "YYYY-MM-DD" format. Pass an empty value
+		to remove the due date.
+
+	-d, --description <DESCRIPTION>
+		A description of the milestone.
+
+	-o, --sort <KEY>
+		Sort displayed milestones by "due_on" (default) or "completeness".
+
+	-^, --sort-ascending
+		Sort by ascending order instead of descending.
+
+	-f, --format <FORMAT>
+		Pretty print the list of milestones using format <FORMAT> (default:
+		"%sC%>(5)%i%Creset  %t  (%o open, %c closed)%  dD%n"). See the "PRETTY
+		FORMATS" section of git-log(1) for some additional details on how
+		placeholders are used in format. The available placeholders are:
+
+		%I: milestone number
+
+		%i: milestone number prefixed with "#"
+
+		%U: the URL of this milestone
+
+		%S: state (i.e. "open", "closed")
+
+		%sC: set color to green or red, depending on milestone state.
+
+		%t: title
+
+		%b: description
+
+		%o: number of open issues
+
+		%c: number of closed issues
+
+		%p: percentage of issues that are closed
+
+		%dD: due date-only (no time of day)
+
+		%dr: due date, relative (i.e. "in 3 days", "2 days ago")
+
+		%dI: due date, ISO 8601 format
+
+		%cD: created date-only (no time of day)
+
+		%cr: created date, relative
+
+		%cI: created date, ISO 8601 format
+
+		%n: newline
+
+		%%: a literal %
+
+	--color[=<WHEN>]
+		Enable colored output even if stdout is not a terminal. <WHEN> can be one
+		of "always" (default for ''--color''), "never", or "auto" (default).
+
+	<MILESTONE>
+		The number or the title of a milestone.
+
+## Examples:
+		$ hub milestone create v2.1 --due 2026-12-01 -d "Bug fixes"
+		$ hub milestone -s all -f "%t: %p%% done%n"
+		$ hub milestone close v2.1
+
+## See also:
+
+hub-issue(1), hub(1)
+`,
+		KnownFlags: `
+		-s, --state STATE
+		-f, --format FMT
+		-o, --sort KEY
+		-^, --sort-ascending
+		--color
+`,
+	}
+
+	cmdListMilestones = &Command{
+		Key: "list",
+		Run: listMilestones,
+		KnownFlags: `
+		-s, --state STATE
+		-f, --format FMT
+		-o, --sort KEY
+		-^, --sort-ascending
+		--color
+`,
+	}
+
+	cmdCreateMilestone = &Command{
+		Key: "create",
+		Run: createMilestone,
+		KnownFlags: `
+		--due DATE
+		-d, --description DESC
+`,
+	}
+
+	cmdEditMilestone = &Command{
+		Key: "edit",
+		Run: editMilestone,
+		KnownFlags: `
+		-t, --title TITLE
+		--due DATE
+		-d, --description DESC
+		-s, --state STATE
+`,
+	}
+
+	cmdCloseMilestone = &Command{
+		Key: "close",
+		Run: closeMilestone,
+	}
+
+	cmdDeleteMilestone = &Command{
+		Key: "delete",
+		Run: deleteMilestone,
+	}
+)
+
+func init() {
+	cmdMilestone.Use(cmdListMilestones)
+	cmdMilestone.Use(cmdCreateMilestone)
+	cmdMilestone.Use(cmdEditMilestone)
+	cmdMilestone.Use(cmdCloseMilestone)
+	cmdMilestone.Use(cmdDeleteMilestone)
+	CmdRunner.Use(cmdMilestone)
+}
+
+func listMilestones(cmd *Command, args *Args) {
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would request list of milestones for %s\n", project)
+		return
+	}
+
+	filters := map[string]interface{}{}
+	if args.Flag.HasReceived("--state") {
+		filters["state"] = args.Flag.Value("--state")
+	}
+	if args.Flag.HasReceived("--sort") {
+		filters["sort"] = args.Flag.Value("--sort")
+	}
+	if args.Flag.Bool("--sort-ascending") {
+		filters["direction"] = "asc"
+	} else {
+		filters["direction"] = "desc"
+	}
+
+	milestones, err := gh.FetchMilestones(project, filters)
+	utils.Check(err)
+
+	format := "%sC%>(5)%i%Creset  %t  (%o open, %c closed)%  dD%n"
+	if args.Flag.HasReceived("--format") {
+		format = args.Flag.Value("--format")
+	}
+	colorize := colorizeOutput(args.Flag.HasReceived("--color"), args.Flag.Value("--color"))
+	now := time.Now()
+	for _, milestone := range milestones {
+		ui.Print(formatMilestone(milestone, format, colorize, now))
+	}
+}
+
+func formatMilestone(milestone github.Milestone, format string, colorize bool, now time.Time) string {
+	var stateColorSwitch string
+	if colorize {
+		milestoneColor := 32
+		if milestone.State == "closed" {
+			milestoneColor = 31
+		}
+		stateColorSwitch = fmt.Sprintf("\033[%dm", milestoneColor)
+	}
+
+	percentClosed := 0
+	if total := milestone.OpenIssues + milestone.ClosedIssues; total > 0 {
+		percentClosed = milestone.ClosedIssues * 100 / total
+	}
+
+	var dueDate, dueAtISO8601, dueAtRelative string
+	if !milestone.DueOn.IsZero() {
+		dueDate = milestone.DueOn.Format("02 Jan 2006")
+		dueAtISO8601 = milestone.DueOn.Format(time.RFC3339)
+		dueAtRelative = relativeDueDate(milestone.DueOn, now)
+	}
+
+	var createdDate, createdAtISO8601, createdAtRelative string
+	if !milestone.CreatedAt.IsZero() {
+		createdDate = milestone.CreatedAt.Format("02 Jan 2006")
+		createdAtISO8601 = milestone.CreatedAt.Format(time.RFC3339)
+		createdAtRelative = utils.TimeAgo(milestone.CreatedAt)
+	}
+
+	placeholders := map[string]string{
+		"I":  fmt.Sprintf("%d", milestone.Number),
+		"i":  fmt.Sprintf("#%d", milestone.Number),
+		"U":  milestone.HTMLURL,
+		"S":  milestone.State,
+		"sC": stateColorSwitch,
+		"t":  milestone.Title,
+		"b":  milestone.Description,
+		"o":  fmt.Sprintf("%d", milestone.OpenIssues),
+		"c":  fmt.Sprintf("%d", milestone.ClosedIssues),
+		"p":  fmt.Sprintf("%d", percentClosed),
+		"dD": dueDate,
+		"dI": dueAtISO8601,
+		"dr": dueAtRelative,
+		"cD": createdDate,
+		"cI": createdAtISO8601,
+		"cr": createdAtRelative,
+	}
+
+	return ui.Expand(format, placeholders, colorize)
+}
+
+// relativeDueDate describes how many days away a due date is, ignoring the
+// time of day
+func relativeDueDate(due, now time.Time) string {
+	dueDay := time.Date(due.Year(), due.Month(), due.Day(), 0, 0, 0, 0, time.UTC)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	days := int(dueDay.Sub(today).Hours() / 24)
+
+	switch {
+	case days == 0:
+		return "today"
+	case days == 1:
+		return "tomorrow"
+	case days == -1:
+		return "yesterday"
+	case days > 0:
+		return fmt.Sprintf("in %d days", days)
+	default:
+		return fmt.Sprintf("%d days ago", -days)
+	}
+}
+
+func createMilestone(cmd *Command, args *Args) {
+	if args.ParamsSize() != 1 {
+		utils.Check(cmd.UsageError(""))
+	}
+
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	params := map[string]interface{}{
+		"title": args.GetParam(0),
+	}
+	utils.Check(setMilestoneFieldsFromArgs(params, args))
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would create milestone '%s' for %s\n", params["title"], project)
+		return
+	}
+
+	gh := github.NewClient(project.Host)
+	milestone, err := gh.CreateMilestone(project, params)
+	utils.Check(err)
+
+	ui.Println(milestone.HTMLURL)
+}
+
+func editMilestone(cmd *Command, args *Args) {
+	if args.ParamsSize() != 1 {
+		utils.Check(cmd.UsageError(""))
+	}
+	if !hasField(args, "--title", "--due", "--description", "--state") {
+		utils.Check(cmd.UsageError("please specify fields to update"))
+	}
+
+	params := map[string]interface{}{}
+	if args.Flag.HasReceived("--title") {
+		params["title"] = args.Flag.Value("--title")
+	}
+	if args.Flag.HasReceived("--state") {
+		params["state"] = args.Flag.Value("--state")
+	}
+	utils.Check(setMilestoneFieldsFromArgs(params, args))
+
+	updateMilestone(args, "update", params)
+}
+
+func closeMilestone(cmd *Command, args *Args) {
+	if args.ParamsSize() != 1 {
+		utils.Check(cmd.UsageError(""))
+	}
+
+	updateMilestone(args, "close", map[string]interface{}{
+		"state": "closed",
+	})
+}
+
+func updateMilestone(args *Args, action string, params map[string]interface{}) {
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	gh := github.NewClient(project.Host)
+	milestoneNumber, err := milestoneValueToNumber(args.GetParam(0), gh, project, "all")
+	utils.Check(err)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would %s milestone #%d for %s\n", action, milestoneNumber, project)
+		return
+	}
+
+	milestone, err := gh.UpdateMilestone(project, milestoneNumber, params)
+	utils.Check(err)
+
+	ui.Println(milestone.HTMLURL)
+}
+
+func deleteMilestone(cmd *Command, args *Args) {
+	if args.ParamsSize() != 1 {
+		utils.Check(cmd.UsageError(""))
+	}
+
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	gh := github.NewClient(project.Host)
+	milestoneNumber, err := milestoneValueToNumber(args.GetParam(0), gh, project, "all")
+	utils.Check(err)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would delete milestone #%d for %s\n", milestoneNumber, project)
+		return
+	}
+
+	err = gh.DeleteMilestone(project, milestoneNumber)
+	utils.Check(err)
+}
+
+func setMilestoneFieldsFromArgs(params map[string]interface{}, args *Args) error {
+	if args.Flag.HasReceived("--description") {
+		params["description"] = args.Flag.Value("--description")
+	}
+	if args.Flag.HasReceived("--due") {
+		dueOn, err := parseMilestoneDueDate(args.Flag.Value("--due"))
+		if err != nil {
+			return err
+		}
+		if dueOn == "" {
+			params["due_on"] = nil
+		} else {
+			params["due_on"] = dueOn
+		}
+	}
+	return nil
+}
+
+// parseMilestoneDueDate turns a "YYYY-MM-DD" date into a timestamp for the API.
+// GitHub only keeps the date part, and picking noon UTC avoids the date being
+// shifted by a day when it gets interpreted in a different timezone.
+func parseMilestoneDueDate(value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return "", fmt.Errorf("invalid due date: '%s' (expected YYYY-MM-DD)", value)
+	}
+	return date.Add(12 * time.Hour).Format(time.RFC3339), nil
+}
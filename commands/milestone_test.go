@@ -0,0 +1,57 @@
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/internal/assert"
+)
+
+func TestFormatMilestone(t *testing.T) {
+	now := time.Date(2026, 10, 18, 15, 0, 0, 0, time.UTC)
+	milestone := github.Milestone{
+		Number:       7,
+		Title:        "v2.1",
+		State:        "open",
+		OpenIssues:   3,
+		ClosedIssues: 1,
+		DueOn:        time.Date(2026, 10, 21, 7, 0, 0, 0, time.UTC),
+	}
+
+	assert.Equal(t, "   #7  v2.1  (3 open, 1 closed)  21 Oct 2026\n",
+		formatMilestone(milestone, "%sC%>(5)%i%Creset  %t  (%o open, %c closed)%  dD%n", false, now))
+	assert.Equal(t, "v2.1: 25% done, due in 3 days",
+		formatMilestone(milestone, "%t: %p%% done, due %dr", false, now))
+	assert.Equal(t, "\033[32m#7\033[m",
+		formatMilestone(milestone, "%sC%i%Creset", true, now))
+
+	milestone.DueOn = time.Time{}
+	milestone.OpenIssues = 0
+	milestone.ClosedIssues = 0
+	assert.Equal(t, "   #7  v2.1  (0 open, 0 closed)\n",
+		formatMilestone(milestone, "%sC%>(5)%i%Creset  %t  (%o open, %c closed)%  dD%n", false, now))
+	assert.Equal(t, "0", formatMilestone(milestone, "%p", false, now))
+}
+
+func TestRelativeDueDate(t *testing.T) {
+	now := time.Date(2026, 10, 18, 23, 0, 0, 0, time.UTC)
+	assert.Equal(t, "today", relativeDueDate(time.Date(2026, 10, 18, 7, 0, 0, 0, time.UTC), now))
+	assert.Equal(t, "tomorrow", relativeDueDate(time.Date(2026, 10, 19, 7, 0, 0, 0, time.UTC), now))
+	assert.Equal(t, "yesterday", relativeDueDate(time.Date(2026, 10, 17, 7, 0, 0, 0, time.UTC), now))
+	assert.Equal(t, "in 14 days", relativeDueDate(time.Date(2026, 11, 1, 7, 0, 0, 0, time.UTC), now))
+	assert.Equal(t, "5 days ago", relativeDueDate(time.Date(2026, 10, 13, 7, 0, 0, 0, time.UTC), now))
+}
+
+func TestParseMilestoneDueDate(t *testing.T) {
+	dueOn, err := parseMilestoneDueDate("2026-12-01")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2026-12-01T12:00:00Z", dueOn)
+
+	dueOn, err = parseMilestoneDueDate("")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "", dueOn)
+
+	_, err = parseMilestoneDueDate("next week")
+	assert.Equal(t, "invalid due date: 'next week' (expected YYYY-MM-DD)", err.Error())
+}
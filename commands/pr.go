@@ -2,8 +2,13 @@ package commands
 
 import (
 	"fmt"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/github/hub/v2/git"
 	"github.com/github/hub/v2/github"
@@ -16,10 +21,30 @@ var (
 		Run: printHelp,
 		Usage: `
 pr list [-s <STATE>] [-h <HEAD>] [-b <BASE>] [-o <SORT_KEY> [-^]] [-f <FORMAT>] [-L <LIMIT>]
-pr checkout <PR-NUMBER> [<BRANCH>]
+pr list [--author <USER>] [--assignee <USER>] [--label <LABELS>] [--review-requested <USER>] [--draft | --no-draft] [--search <QUERY>]
+pr checkout <PR-NUMBER> [<BRANCH>] [--worktree[=<PATH>]] [--force]
+pr checkout --cleanup
+pr status [-f <FORMAT>]
 pr show [-uc] [-f <FORMAT>] [-h <HEAD>]
 pr show [-uc] [-f <FORMAT>] <PR-NUMBER>
+pr show --comments [<PR-NUMBER>]
 pr merge [-d] [--squash | --rebase] <PR-NUMBER> [-m <MESSAGE> | -F <FILE>] [--head-sha <COMMIT-SHA>]
+pr merge --auto [--squash | --rebase] <PR-NUMBER> [-m <MESSAGE> | -F <FILE>] [--head-sha <COMMIT-SHA>]
+pr merge --disable-auto <PR-NUMBER>
+pr merge --check <PR-NUMBER>
+pr edit <PR-NUMBER> [-m <MESSAGE> | -F <FILE>] [--edit] [-b <BASE>] [-r <REVIEWERS>] [-a <ASSIGNEES>] [-M <MILESTONE>] [-l <LABELS>]
+pr stack [-p] [--draft]
+pr update-branch [--rebase] [--fast-forward] [<PR-NUMBER>]
+pr checks [--watch [--interval <SECONDS>] [--fail-fast]] [-f <FORMAT>] [<PR-NUMBER>]
+pr close [-d] [-m <MESSAGE> | -F <FILE>] [<PR-NUMBER>]
+pr reopen [-m <MESSAGE> | -F <FILE>] [<PR-NUMBER>]
+pr ready [<PR-NUMBER>]
+pr comment [<PR-NUMBER>] [-m <MESSAGE> | -F <FILE>] [--edit] [--edit-last]
+pr comment [<PR-NUMBER>] --delete <ID>
+pr draft [<PR-NUMBER>]
+pr diff [--name-only | --stat] [--color[=<WHEN>]] <PR-NUMBER>
+pr revert <PR-NUMBER> [--draft]
+pr review <PR-NUMBER> (--approve | --request-changes | --comment) [-m <MESSAGE> | -F <FILE>] [--edit] [--inline <PATH>:<LINE>:<TEXT>]
 `,
 		Long: `Manage GitHub Pull Requests for the current repository.
 
@@ -28,24 +53,149 @@ pr merge [-d] [--squash | --rebase] <PR-NUMBER> [-m <MESSAGE> | -F <FILE>] [--he
 	* _list_:
 		List pull requests in the current repository.
 
+		Filtering by ''--author'', ''--assignee'', ''--label'',
+		''--review-requested'', ''--draft'', ''--no-draft'', or ''--search'' is
+		performed using the GitHub search API.
+
 	* _checkout_:
 		Check out the head of a pull request in a new branch.
 
 		To update the pull request with new commits, use ''git push''.
 
+		When the local branch already exists but has diverged from the head of the
+		pull request, e.g. because the pull request was force-pushed to, the
+		differing commits are listed and the new head is checked out in a new
+		"<BRANCH>-v2" branch. Use ''--force'' to reset the existing branch instead.
+
+		With ''--worktree'', the pull request is checked out in a new working tree
+		instead, leaving the current one untouched. Working trees created this way
+		can be removed with ''--cleanup'' once their pull requests are closed.
+
+	* _status_:
+		Show the pull request for the current branch together with the state of
+		its checks, pull requests that request a review from you or one of your
+		teams, and pull requests that you have opened. With ''--format'', print
+		only the pull request for the current branch, which is useful in shell
+		prompts.
+
 	* _show_:
 		Open a pull request page in a web browser. When no <PR-NUMBER> is
 		specified, <HEAD> is used to look up open pull requests and defaults to
 		the current branch name. With ''--format'', print information about the
-		pull request instead of opening it.
+		pull request instead of opening it. With ''--comments'', print its review
+		threads together with the file and line that each of them refers to.
 
 	* _merge_:
 		Merge a pull request in the current repository remotely. Select an
 		alternate merge method with ''--squash'' or ''--rebase''. Change the
 		commit subject and body with ''--message'' or ''--file''.
 
+		With ''--auto'', enable auto-merge instead so that the pull request gets
+		merged with the chosen method as soon as all its requirements are met. Use
+		''--disable-auto'' to turn auto-merge off again. When the base branch
+		requires a merge queue, ''--auto'' adds the pull request to the queue and
+		''--disable-auto'' removes it from there. The merge queue decides how pull
+		requests get merged, so a merge method or commit message can't be given.
+
+		With ''--check'', report what prevents the pull request from being merged
+		without merging it. The exit status is 0 when the pull request can be
+		merged, and otherwise reflects the first of these blockers that applies:
+
+		2: there are merge conflicts with the base branch
+
+		3: required approving reviews are missing or changes were requested
+
+		4: required checks are pending or have failed
+
+		5: the head branch is not up to date with the base branch
+
+		6: any other reason, e.g. the pull request is closed or a draft
+
+		7: nothing is known to block the merge, but the branch protection rules
+		of the base branch could not be read
+
+	* _edit_:
+		Update fields of an existing pull request. Only the fields that were
+		given are changed. Use ''--edit'' to edit the title and description
+		interactively in the text editor.
+
+	* _stack_:
+		Open or update one pull request for each link in the stack of branches
+		that the current branch belongs to. A stack consists of local branches
+		whose upstream is another local branch, e.g. one that was created with
+		''git checkout -b <BRANCH> --track <PARENT>''. Each pull request is based
+		on the branch below it, and pull requests whose base has since been merged
+		are retargeted to the base of the merged pull request.
+
+	* _update-branch_:
+		Bring the head branch of a pull request up to date with its base branch by
+		merging the base branch into it on GitHub, or by rebasing it onto the base
+		branch with ''--rebase''. When no <PR-NUMBER> is specified, the open pull
+		request for the current branch is used. The update is refused if the head
+		branch has changed since the pull request was last fetched.
+
+	* _comment_:
+		Post a comment on a pull request. When no <PR-NUMBER> is specified, the
+		open pull request for the current branch is used. Unless ''--message'' or
+		''--file'' were supplied, a text editor will open to author the comment in.
+		Use ''--edit-last'' to change your most recent comment on the pull request
+		instead, or ''--delete'' to delete a comment.
+
+	* _checks_:
+		Show the status of GitHub checks for the head commit of a pull request.
+		When no <PR-NUMBER> is specified, the open pull request for the current
+		branch is used. See hub-ci-status(1) for the placeholders available in
+		''--format'' and for the meaning of exit statuses.
+
+	* _close_:
+		Close a pull request without merging it. When no <PR-NUMBER> is specified,
+		the open pull request for the current branch is used. Leave a closing
+		comment with ''--message'' or ''--file''.
+
+	* _reopen_:
+		Reopen a closed pull request. When no <PR-NUMBER> is specified, the closed
+		pull request for the current branch is used.
+
+	* _ready_:
+		Mark a draft pull request as ready for review. When no <PR-NUMBER> is
+		specified, the open pull request for the current branch is used.
+
+	* _draft_:
+		Convert a pull request back to a draft. When no <PR-NUMBER> is specified,
+		the open pull request for the current branch is used.
+
+	* _diff_:
+		Print the changes introduced by a pull request in unified diff format. The
+		output is sent through the pager that git is configured to use when
+		standard output is a terminal.
+
+	* _revert_:
+		Revert a merged pull request. A "revert-<PR-NUMBER>-<BRANCH>" branch is
+		created off the base branch of the pull request, the changes it introduced
+		are reverted in it, and a new pull request that references the original one
+		is opened from it after it has been pushed. Pull requests that were merged
+		using a merge commit, squashed, or rebased are supported.
+
+	* _review_:
+		Submit a review for a pull request in the current repository. Exactly one
+		of ''--approve'', ''--request-changes'', or ''--comment'' must be given.
+		Unless the review is an approval or ''--message'' or ''--file'' were
+		supplied, a text editor will open to author the review body in.
+
 ## Options:
 
+	--worktree[=<PATH>]
+		Check out a pull request in a new git worktree at <PATH> (default:
+		"<TOPLEVEL>-pr-<PR-NUMBER>" next to the current working tree).
+
+	--cleanup
+		Remove the working trees created by ''checkout --worktree'' whose pull
+		requests have been closed or merged.
+
+	--force
+		Reset a local branch that has diverged from the head of the pull request
+		when checking it out.
+
 	-s, --state <STATE>
 		Filter pull requests by <STATE>. Supported values are: "open" (default),
 		"closed", "merged", or "all".
@@ -57,6 +207,31 @@ pr merge [-d] [--squash | --rebase] <PR-NUMBER> [-m <MESSAGE> | -F <FILE>] [--he
 	-b, --base <BRANCH>
 		Show pull requests based off the specified <BRANCH>.
 
+		When editing, change the base branch of the pull request to <BRANCH>.
+
+	--author <USER>
+		Show pull requests opened by <USER>.
+
+	--assignee <USER>
+		Show pull requests assigned to <USER>.
+
+	--label <LABELS>
+		Show pull requests with all of the given comma-separated <LABELS>.
+
+	--review-requested <USER>
+		Show pull requests where a review was requested from <USER>. Use "@me" for
+		the current user, or "ORG/TEAM" for a team.
+
+	--draft
+		Show only draft pull requests.
+
+	--no-draft
+		Exclude draft pull requests.
+
+	--search <QUERY>
+		Show pull requests matching the search <QUERY>. See "Searching issues and
+		pull requests" in GitHub Help for the available qualifiers.
+
 	-f, --format <FORMAT>
 		Pretty print the list of pull requests using format <FORMAT> (default:
 		"%pC%>(8)%i%Creset  %t%  l%n"). See the "PRETTY FORMATS" section of
@@ -101,6 +276,12 @@ pr merge [-d] [--squash | --rebase] <PR-NUMBER> [-m <MESSAGE> | -F <FILE>] [--he
 
 		%rs: comma-separated list of requested reviewers
 
+		%ra: number of approving reviews
+
+		%rc: comma-separated list of reviewers who requested changes
+
+		%rt: number of unresolved review threads
+
 		%Mn: milestone number
 
 		%Mt: milestone title
@@ -129,6 +310,10 @@ pr merge [-d] [--squash | --rebase] <PR-NUMBER> [-m <MESSAGE> | -F <FILE>] [--he
 
 		%mI: merged date, ISO 8601 format
 
+		%cS: combined state of checks for the head commit (only in ''status'')
+
+		%cC: set color according to the state of checks (only in ''status'')
+
 		%n: newline
 
 		%%: a literal %
@@ -152,10 +337,22 @@ pr merge [-d] [--squash | --rebase] <PR-NUMBER> [-m <MESSAGE> | -F <FILE>] [--he
 	-c, --copy
 		Put the pull request URL to clipboard instead of opening it.
 
+	--comments
+		Print the review threads of a pull request instead of opening it.
+
 	-m, --message <MESSAGE>
 		The text up to the first blank line in <MESSAGE> is treated as the commit
 		subject for the merge commit, and the rest is used as commit body.
 
+		When editing, the text up to the first blank line is treated as the pull
+		request title, and the rest is used as pull request description.
+
+		When closing or reopening, <MESSAGE> is posted as a comment on the pull
+		request.
+
+		When reviewing, the whole <MESSAGE> is used as the review body in Markdown
+		format. The same applies to comments.
+
 		When multiple ''--message'' are passed, their values are concatenated with a
 		blank line in-between.
 
@@ -163,6 +360,27 @@ pr merge [-d] [--squash | --rebase] <PR-NUMBER> [-m <MESSAGE> | -F <FILE>] [--he
 		Read the subject and body for the merge commit from <FILE>. Pass "-" to read
 		from standard input instead. See ''--message'' for the formatting rules.
 
+	-e, --edit
+		Open the pull request title and description, or the review body, in a text
+		editor before submitting. This can be used in combination with ''--message''
+		or ''--file''.
+
+	-r, --reviewer <USERS>
+		A comma-separated list (no spaces around the comma) of GitHub handles to
+		request a review from.
+
+	-a, --assign <USERS>
+		A comma-separated list (no spaces around the comma) of GitHub handles to
+		assign to this pull request.
+
+	-M, --milestone <NAME>
+		The milestone name to set on this pull request. Pass an empty value to
+		clear the milestone.
+
+	-l, --labels <LABELS>
+		A comma-separated list (no spaces around the comma) of labels to set on
+		this pull request. This replaces any existing labels.
+
 	--head-sha <COMMIT-SHA>
 		Ensure that the head of the pull request matches the commit SHA when merging.
 
@@ -172,8 +390,76 @@ pr merge [-d] [--squash | --rebase] <PR-NUMBER> [-m <MESSAGE> | -F <FILE>] [--he
 	--rebase
 		Rebase commits on top of the base branch when merging a pull request.
 
+		With ''update-branch'', rebase the head branch onto the base branch instead
+		of merging the base branch into it.
+
+	--auto
+		Enable auto-merge for a pull request, or add it to the merge queue,
+		instead of merging it right away.
+
+	--disable-auto
+		Disable auto-merge for a pull request, or remove it from the merge queue.
+
+	--check
+		Check whether a pull request can be merged instead of merging it.
+
 	-d, --delete-branch
-		Delete the head branch after successfully merging a pull request.
+		Delete the head branch after successfully merging or closing a pull request.
+		Branches of pull requests from forks are left untouched.
+
+	-p, --push
+		Push each branch of the stack before opening or updating its pull request.
+
+	--draft
+		Create new pull requests in the stack, or the revert pull request, as
+		drafts.
+
+	--fast-forward
+		After ''update-branch'', fast-forward the local branch of the pull request
+		to the updated head if that branch is currently checked out. With
+		''--rebase'', the local branch is reset to the rebased head, but only if it
+		was at the head of the pull request before the rebase.
+
+	--edit-last
+		Edit the most recent comment of the current user instead of posting a new
+		one. Without ''--message'' or ''--file'', the existing comment is opened in
+		a text editor.
+
+	--delete <ID>
+		Delete the comment with the given <ID>.
+
+	--watch
+		Keep polling the checks of a pull request until none of them are pending.
+		If no checks have been reported yet, polling continues for a few rounds
+		before giving up.
+
+	--interval <SECONDS>
+		How often to poll checks in ''--watch'' mode (default: 10).
+
+	--fail-fast
+		Stop watching checks as soon as one of them has failed.
+
+	--name-only
+		Only print the names of files changed in a pull request.
+
+	--stat
+		Print a diffstat summary of files changed in a pull request instead of the
+		full diff.
+
+	--approve
+		Approve the pull request.
+
+	--request-changes
+		Request changes to the pull request. A review body is required.
+
+	--comment
+		Submit general feedback without explicit approval. A review body is required.
+
+	--inline <PATH>:<LINE>:<TEXT>
+		Add <TEXT> as a review comment on <LINE> of file <PATH> in the pull request
+		diff. Prefix <LINE> with "-" to comment on a line that was removed, in
+		which case it refers to the line number in the original file. This option
+		can be passed multiple times.
 
 ## See also:
 
@@ -182,9 +468,13 @@ hub-issue(1), hub-pull-request(1), hub(1)
 	}
 
 	cmdCheckoutPr = &Command{
-		Key:        "checkout",
-		Run:        checkoutPr,
-		KnownFlags: "\n",
+		Key: "checkout",
+		Run: checkoutPr,
+		KnownFlags: `
+		--worktree[=PATH]
+		--cleanup
+		--force
+		`,
 	}
 
 	cmdListPulls = &Command{
@@ -193,6 +483,15 @@ hub-issue(1), hub-pull-request(1), hub(1)
 		Long: cmdPr.Long,
 	}
 
+	cmdStatusPr = &Command{
+		Key: "status",
+		Run: statusPr,
+		KnownFlags: `
+		-f, --format FORMAT
+		--color
+		`,
+	}
+
 	cmdShowPr = &Command{
 		Key: "show",
 		Run: showPr,
@@ -202,6 +501,7 @@ hub-issue(1), hub-pull-request(1), hub(1)
 		-c, --copy
 		-f, --format FORMAT
 		--color
+		--comments
 		`,
 	}
 
@@ -214,16 +514,152 @@ hub-issue(1), hub-pull-request(1), hub(1)
 		--head-sha COMMIT
 		--squash
 		--rebase
+		--auto
+		--disable-auto
+		--check
+		-d, --delete-branch
+		`,
+	}
+
+	cmdEditPr = &Command{
+		Key: "edit",
+		Run: editPr,
+		KnownFlags: `
+		-m, --message MESSAGE
+		-F, --file FILE
+		-e, --edit
+		-b, --base BASE
+		-r, --reviewer USERS
+		-a, --assign USERS
+		-M, --milestone NAME
+		-l, --labels LABELS
+		`,
+	}
+
+	cmdStackPr = &Command{
+		Key: "stack",
+		Run: stackPr,
+		KnownFlags: `
+		-p, --push
+		--draft
+		`,
+	}
+
+	cmdUpdateBranchPr = &Command{
+		Key: "update-branch",
+		Run: updateBranchPr,
+		KnownFlags: `
+		--rebase
+		--fast-forward
+		`,
+	}
+
+	cmdCommentPr = &Command{
+		Key: "comment",
+		Run: commentPr,
+		KnownFlags: `
+		-m, --message MESSAGE
+		-F, --file FILE
+		-e, --edit
+		--edit-last
+		--delete ID
+		`,
+	}
+
+	cmdChecksPr = &Command{
+		Key: "checks",
+		Run: checksPr,
+		KnownFlags: `
+		--watch
+		--interval SECONDS
+		--fail-fast
+		-f, --format FORMAT
+		--color
+		`,
+	}
+
+	cmdClosePr = &Command{
+		Key: "close",
+		Run: closePr,
+		KnownFlags: `
+		-m, --message MESSAGE
+		-F, --file FILE
 		-d, --delete-branch
 		`,
 	}
+
+	cmdReopenPr = &Command{
+		Key: "reopen",
+		Run: reopenPr,
+		KnownFlags: `
+		-m, --message MESSAGE
+		-F, --file FILE
+		`,
+	}
+
+	cmdReadyPr = &Command{
+		Key:        "ready",
+		Run:        readyPr,
+		KnownFlags: "\n",
+	}
+
+	cmdDraftPr = &Command{
+		Key:        "draft",
+		Run:        draftPr,
+		KnownFlags: "\n",
+	}
+
+	cmdDiffPr = &Command{
+		Key: "diff",
+		Run: diffPr,
+		KnownFlags: `
+		--name-only
+		--stat
+		--color
+		`,
+	}
+
+	cmdRevertPr = &Command{
+		Key: "revert",
+		Run: revertPr,
+		KnownFlags: `
+		--draft
+		`,
+	}
+
+	cmdReviewPr = &Command{
+		Key: "review",
+		Run: reviewPr,
+		KnownFlags: `
+		--approve
+		--request-changes
+		--comment
+		-m, --message MESSAGE
+		-F, --file FILE
+		-e, --edit
+		--inline COMMENT
+		`,
+	}
 )
 
 func init() {
 	cmdPr.Use(cmdListPulls)
 	cmdPr.Use(cmdCheckoutPr)
+	cmdPr.Use(cmdStatusPr)
 	cmdPr.Use(cmdShowPr)
 	cmdPr.Use(cmdMergePr)
+	cmdPr.Use(cmdEditPr)
+	cmdPr.Use(cmdChecksPr)
+	cmdPr.Use(cmdCommentPr)
+	cmdPr.Use(cmdStackPr)
+	cmdPr.Use(cmdUpdateBranchPr)
+	cmdPr.Use(cmdClosePr)
+	cmdPr.Use(cmdReopenPr)
+	cmdPr.Use(cmdReadyPr)
+	cmdPr.Use(cmdDraftPr)
+	cmdPr.Use(cmdDiffPr)
+	cmdPr.Use(cmdRevertPr)
+	cmdPr.Use(cmdReviewPr)
 	CmdRunner.Use(cmdPr)
 }
 
@@ -282,18 +718,136 @@ func listPulls(cmd *Command, args *Args) {
 		flagPullRequestFormat = "%pC%>(8)%i%Creset  %t%  l%n"
 	}
 
-	pulls, err := gh.FetchPullRequests(project, filters, flagPullRequestLimit, func(pr *github.PullRequest) bool {
-		return !(onlyMerged && pr.MergedAt.IsZero())
-	})
-	utils.Check(err)
+	var pulls []github.PullRequest
+	if hasField(args, "--author", "--assignee", "--label", "--review-requested", "--draft", "--no-draft", "--search") {
+		query, err := pullRequestSearchQuery(project, args)
+		if err != nil {
+			utils.Check(cmd.UsageError(err.Error()))
+		}
+		// search only matches the name of the head branch, so pull requests from
+		// same-named branches in other forks get filtered out here
+		var headFilter func(*github.PullRequest) bool
+		if args.Flag.HasReceived("--head") {
+			headOwner := project.Owner
+			if head := args.Flag.Value("--head"); strings.Contains(head, ":") {
+				headOwner = strings.SplitN(head, ":", 2)[0]
+			}
+			headFilter = func(pr *github.PullRequest) bool {
+				return pr.Head.Repo != nil && strings.EqualFold(pr.Head.Repo.Owner.Login, headOwner)
+			}
+		}
+		pulls, err = gh.SearchPullRequests(project, query, flagPullRequestLimit, headFilter)
+		utils.Check(err)
+	} else {
+		pulls, err = gh.FetchPullRequests(project, filters, flagPullRequestLimit, func(pr *github.PullRequest) bool {
+			return !(onlyMerged && pr.MergedAt.IsZero())
+		})
+		utils.Check(err)
+	}
 
 	colorize := colorizeOutput(args.Flag.HasReceived("--color"), args.Flag.Value("--color"))
+	var reviewData map[int]*github.PullRequestReviewData
+	if usesReviewPlaceholders(flagPullRequestFormat) {
+		prNumbers := []int{}
+		for _, pr := range pulls {
+			prNumbers = append(prNumbers, pr.Number)
+		}
+		reviewData, err = gh.PullRequestsReviewData(project, prNumbers)
+		utils.Check(err)
+	}
 	for _, pr := range pulls {
-		ui.Print(formatPullRequest(pr, flagPullRequestFormat, colorize))
+		if reviewData != nil {
+			prReviewData := reviewData[pr.Number]
+			if prReviewData == nil {
+				prReviewData = &github.PullRequestReviewData{}
+			}
+			ui.Print(formatPullRequestWithReviews(pr, prReviewData, flagPullRequestFormat, colorize))
+		} else {
+			ui.Print(formatPullRequest(pr, flagPullRequestFormat, colorize))
+		}
+	}
+}
+
+func pullRequestSearchQuery(project *github.Project, args *Args) (string, error) {
+	qualifiers := []string{
+		fmt.Sprintf("repo:%s/%s", project.Owner, project.Name),
+		"is:pr",
+	}
+
+	state := "open"
+	if args.Flag.HasReceived("--state") {
+		state = args.Flag.Value("--state")
+	}
+	if state != "all" {
+		qualifiers = append(qualifiers, "is:"+state)
+	}
+
+	if args.Flag.HasReceived("--base") {
+		qualifiers = append(qualifiers, "base:"+args.Flag.Value("--base"))
+	}
+	if args.Flag.HasReceived("--head") {
+		head := args.Flag.Value("--head")
+		if i := strings.IndexByte(head, ':'); i >= 0 {
+			head = head[i+1:]
+		}
+		qualifiers = append(qualifiers, "head:"+head)
+	}
+
+	for _, name := range []string{"author", "assignee", "review-requested"} {
+		if args.Flag.HasReceived("--" + name) {
+			value := args.Flag.Value("--" + name)
+			qualifier := name
+			if name == "review-requested" && strings.Contains(value, "/") {
+				qualifier = "team-review-requested"
+			}
+			qualifiers = append(qualifiers, fmt.Sprintf("%s:%s", qualifier, value))
+		}
+	}
+	for _, label := range commaSeparated(args.Flag.AllValues("--label")) {
+		if strings.ContainsAny(label, " \t") {
+			label = fmt.Sprintf("%q", label)
+		}
+		qualifiers = append(qualifiers, "label:"+label)
+	}
+
+	if args.Flag.Bool("--draft") && args.Flag.Bool("--no-draft") {
+		return "", fmt.Errorf("--draft and --no-draft cannot be used together")
+	} else if args.Flag.Bool("--draft") {
+		qualifiers = append(qualifiers, "draft:true")
+	} else if args.Flag.Bool("--no-draft") {
+		qualifiers = append(qualifiers, "draft:false")
+	}
+
+	sortKey := "created"
+	if args.Flag.HasReceived("--sort") {
+		switch sort := args.Flag.Value("--sort"); sort {
+		case "created", "updated":
+			sortKey = sort
+		case "popularity":
+			sortKey = "comments"
+		default:
+			return "", fmt.Errorf("sorting by '%s' is not supported when searching", sort)
+		}
+	}
+	direction := "desc"
+	if args.Flag.Bool("--sort-ascending") {
+		direction = "asc"
+	}
+	qualifiers = append(qualifiers, fmt.Sprintf("sort:%s-%s", sortKey, direction))
+
+	if args.Flag.HasReceived("--search") {
+		qualifiers = append(qualifiers, args.Flag.Value("--search"))
 	}
+
+	return strings.Join(qualifiers, " "), nil
 }
 
 func checkoutPr(command *Command, args *Args) {
+	if args.Flag.Bool("--cleanup") {
+		cleanupPrWorktrees(args)
+		return
+	}
+
 	words := args.Words()
 	var newBranchName string
 
@@ -318,10 +872,79 @@ func checkoutPr(command *Command, args *Args) {
 	pr, err := client.PullRequest(baseProject, prNumberString)
 	utils.Check(err)
 
-	newArgs, err := transformCheckoutArgs(args, pr, newBranchName)
+	if !args.Flag.HasReceived("--worktree") {
+		newArgs, err := transformCheckoutArgs(args, pr, newBranchName, "", args.Flag.Bool("--force"))
+		utils.Check(err)
+
+		args.Replace(args.Executable, "checkout", newArgs...)
+		return
+	}
+
+	worktreePath := args.Flag.Value("--worktree")
+	if worktreePath == "" {
+		toplevel, err := git.WorkdirName()
+		utils.Check(err)
+		worktreePath = fmt.Sprintf("%s-pr-%d", toplevel, pr.Number)
+	}
+
+	newArgs, err := transformCheckoutArgs(args, pr, newBranchName, worktreePath, args.Flag.Bool("--force"))
+	utils.Check(err)
+
+	// `checkout -b <BRANCH> --no-track <START>` becomes `worktree add -b <BRANCH> --no-track <PATH> <START>`
+	worktreeArgs := []string{"add"}
+	branchName := newArgs[0]
+	if branchName == "-b" || branchName == "-B" {
+		branchName = newArgs[1]
+		worktreeArgs = append(worktreeArgs, newArgs[:len(newArgs)-1]...)
+		worktreeArgs = append(worktreeArgs, worktreePath, newArgs[len(newArgs)-1])
+	} else {
+		worktreeArgs = append(worktreeArgs, worktreePath, branchName)
+	}
+
+	args.After("git", "config", fmt.Sprintf("branch.%s.hubPullRequest", branchName), strconv.Itoa(pr.Number))
+	args.Replace(args.Executable, "worktree", worktreeArgs...)
+}
+
+func cleanupPrWorktrees(args *Args) {
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	worktrees, err := git.Worktrees()
 	utils.Check(err)
 
-	args.Replace(args.Executable, "checkout", newArgs...)
+	args.NoForward()
+	gh := github.NewClient(project.Host)
+
+	for _, worktree := range worktrees {
+		branchName := strings.TrimPrefix(worktree.Branch, "refs/heads/")
+		if branchName == "" {
+			continue
+		}
+		prNumber, err := git.Config(fmt.Sprintf("branch.%s.hubPullRequest", branchName))
+		if err != nil || prNumber == "" {
+			continue
+		}
+
+		pr, err := gh.PullRequest(project, prNumber)
+		utils.Check(err)
+		if pr.State != "closed" {
+			continue
+		}
+
+		if args.Noop {
+			ui.Printf("Would remove worktree %s of pull request #%d\n", worktree.Path, pr.Number)
+			continue
+		}
+
+		if err := git.Spawn("worktree", "remove", worktree.Path); err != nil {
+			ui.Errorf("Error: could not remove worktree %s\n", worktree.Path)
+			continue
+		}
+		ui.Printf("Removed worktree %s of closed pull request #%d\n", worktree.Path, pr.Number)
+	}
 }
 
 func showPr(command *Command, args *Args) {
@@ -347,19 +970,39 @@ func showPr(command *Command, args *Args) {
 			utils.Check(fmt.Errorf("invalid pull request number: '%s'", words[0]))
 		}
 	} else {
-		pr, err = findCurrentPullRequest(localRepo, gh, baseProject, args.Flag.Value("--head"))
+		pr, err = findCurrentPullRequest(localRepo, gh, baseProject, args.Flag.Value("--head"), "open")
 		utils.Check(err)
 		openURL = pr.HTMLURL
 	}
 
 	args.NoForward()
+	if args.Flag.Bool("--comments") {
+		if pr != nil {
+			prNumber = pr.Number
+		}
+		reviewData, err := gh.PullRequestReviewData(baseProject, prNumber)
+		utils.Check(err)
+		if len(reviewData.Threads) == 0 {
+			ui.Printf("There are no review comments on pull request #%d\n", prNumber)
+			return
+		}
+		ui.Print(formatReviewThreads(reviewData.Threads))
+		return
+	}
+
 	if format := args.Flag.Value("--format"); format != "" {
 		if pr == nil {
 			pr, err = gh.PullRequest(baseProject, strconv.Itoa(prNumber))
 			utils.Check(err)
 		}
 		colorize := colorizeOutput(args.Flag.HasReceived("--color"), args.Flag.Value("--color"))
-		ui.Println(formatPullRequest(*pr, format, colorize))
+		if usesReviewPlaceholders(format) {
+			reviewData, err := gh.PullRequestReviewData(baseProject, pr.Number)
+			utils.Check(err)
+			ui.Println(formatPullRequestWithReviews(*pr, reviewData, format, colorize))
+		} else {
+			ui.Println(formatPullRequest(*pr, format, colorize))
+		}
 		return
 	}
 
@@ -369,9 +1012,89 @@ func showPr(command *Command, args *Args) {
 	printBrowseOrCopy(args, openURL, !printURL && !copyURL, copyURL)
 }
 
-func findCurrentPullRequest(localRepo *github.GitHubRepo, gh *github.Client, baseProject *github.Project, headArg string) (*github.PullRequest, error) {
+func statusPr(command *Command, args *Args) {
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	host, err := github.CurrentConfig().PromptForHost(project.Host)
+	utils.Check(err)
+	gh := github.NewClientWithHost(host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would request pull request status for %s\n", project)
+		return
+	}
+
+	var currentPr *github.PullRequest
+	currentBranch, currentBranchErr := localRepo.CurrentBranch()
+	if currentBranchErr == nil {
+		currentPr, err = findCurrentPullRequest(localRepo, gh, project, "", "open")
+		if _, notFound := err.(*pullRequestNotFoundError); !notFound {
+			utils.Check(err)
+		}
+	}
+
+	ciState := ""
+	if currentPr != nil {
+		ciStatus, err := gh.FetchCIStatus(project, currentPr.Head.Sha)
+		utils.Check(err)
+		ciState = ciStatusState(ciStatus.Statuses)
+	}
+
+	colorize := colorizeOutput(args.Flag.HasReceived("--color"), args.Flag.Value("--color"))
+	if args.Flag.HasReceived("--format") {
+		if currentPr != nil {
+			ui.Print(formatPullRequestStatus(*currentPr, ciState, args.Flag.Value("--format"), colorize))
+		}
+		return
+	}
+
+	// "review-requested" also matches requests made to the teams of the user
+	openPulls := fmt.Sprintf("repo:%s/%s is:pr is:open", project.Owner, project.Name)
+	reviewRequested, err := gh.SearchPullRequests(project, openPulls+" review-requested:@me sort:created-desc", 0, nil)
+	utils.Check(err)
+	createdByUser, err := gh.SearchPullRequests(project, openPulls+" author:@me sort:created-desc", 0, nil)
+	utils.Check(err)
+
+	itemFormat := "  %pC%i%Creset  %t%  l%n"
+
+	ui.Println("Current branch:")
+	if currentPr != nil {
+		currentFormat := itemFormat
+		if ciState != "" {
+			currentFormat = "  %pC%i%Creset  %t  %cC%cS%Creset%  l%n"
+		}
+		ui.Print(formatPullRequestStatus(*currentPr, ciState, currentFormat, colorize))
+	} else if currentBranchErr == nil {
+		ui.Printf("  There is no pull request associated with [%s]\n", currentBranch.ShortName())
+	} else {
+		ui.Println("  There is no current branch")
+	}
+
+	ui.Println("\nRequesting a code review from you:")
+	if len(reviewRequested) == 0 {
+		ui.Println("  You have no pull requests to review")
+	}
+	for _, pr := range reviewRequested {
+		ui.Print(formatPullRequest(pr, itemFormat, colorize))
+	}
+
+	ui.Println("\nCreated by you:")
+	if len(createdByUser) == 0 {
+		ui.Println("  You have no open pull requests")
+	}
+	for _, pr := range createdByUser {
+		ui.Print(formatPullRequest(pr, itemFormat, colorize))
+	}
+}
+
+func findCurrentPullRequest(localRepo *github.GitHubRepo, gh *github.Client, baseProject *github.Project, headArg, state string) (*github.PullRequest, error) {
 	filterParams := map[string]interface{}{
-		"state": "open",
+		"state": state,
 	}
 	headWithOwner := ""
 
@@ -400,17 +1123,36 @@ func findCurrentPullRequest(localRepo *github.GitHubRepo, gh *github.Client, bas
 	} else if len(pulls) == 1 {
 		return &pulls[0], nil
 	} else {
-		return nil, fmt.Errorf("no open pull requests found for branch '%s'", headWithOwner)
+		return nil, &pullRequestNotFoundError{state: state, head: headWithOwner}
 	}
 }
 
-func branchTrackingInformation(branch *github.Branch) (string, *github.Branch, error) {
-	branchRemote, err := git.Config(fmt.Sprintf("branch.%s.remote", branch.ShortName()))
-	if branchRemote == "." {
-		err = fmt.Errorf("branch is tracking another local branch")
-	}
-	if err != nil {
-		return "", nil, err
+type pullRequestNotFoundError struct {
+	state string
+	head  string
+}
+
+func (e *pullRequestNotFoundError) Error() string {
+	return fmt.Sprintf("no %s pull requests found for branch '%s'", e.state, e.head)
+}
+
+func findPullRequest(localRepo *github.GitHubRepo, gh *github.Client, baseProject *github.Project, words []string, state string) (*github.PullRequest, error) {
+	if len(words) == 0 {
+		return findCurrentPullRequest(localRepo, gh, baseProject, "", state)
+	}
+	if _, err := strconv.Atoi(words[0]); err != nil {
+		return nil, fmt.Errorf("invalid pull request number: '%s'", words[0])
+	}
+	return gh.PullRequest(baseProject, words[0])
+}
+
+func branchTrackingInformation(branch *github.Branch) (string, *github.Branch, error) {
+	branchRemote, err := git.Config(fmt.Sprintf("branch.%s.remote", branch.ShortName()))
+	if branchRemote == "." {
+		err = fmt.Errorf("branch is tracking another local branch")
+	}
+	if err != nil {
+		return "", nil, err
 	}
 	branchMerge, err := git.Config(fmt.Sprintf("branch.%s.merge", branch.ShortName()))
 	if err != nil {
@@ -495,6 +1237,25 @@ func mergePr(command *Command, args *Args) {
 	project, err := localRepo.MainProject()
 	utils.Check(err)
 
+	if args.Flag.Bool("--check") {
+		if args.Flag.Bool("--auto") || args.Flag.Bool("--disable-auto") || args.Flag.Bool("--delete-branch") {
+			utils.Check(command.UsageError("--check cannot be combined with other merge options"))
+		}
+		checkMergePr(args, project, prNumber)
+		return
+	}
+
+	if args.Flag.Bool("--auto") || args.Flag.Bool("--disable-auto") {
+		if args.Flag.Bool("--auto") && args.Flag.Bool("--disable-auto") {
+			utils.Check(command.UsageError("--auto and --disable-auto cannot be used together"))
+		}
+		if args.Flag.Bool("--delete-branch") {
+			utils.Check(command.UsageError("--delete-branch cannot be used with auto-merge"))
+		}
+		setPullRequestAutoMerge(args, project, prNumber, params)
+		return
+	}
+
 	args.NoForward()
 	if args.Noop {
 		ui.Printf("Would merge pull request #%d for %s\n", prNumber, project)
@@ -520,13 +1281,1441 @@ func mergePr(command *Command, args *Args) {
 	utils.Check(err)
 }
 
-func formatPullRequest(pr github.PullRequest, format string, colorize bool) string {
-	placeholders := formatIssuePlaceholders(github.Issue(pr), colorize)
-	delete(placeholders, "NC")
-	delete(placeholders, "Nc")
+const (
+	mergeBlockedByConflicts = 2 + iota
+	mergeBlockedByReviews
+	mergeBlockedByChecks
+	mergeBlockedByOutdatedBranch
+	mergeBlockedByOther
+	mergeBlockersUnknown
+)
 
-	for key, value := range formatPullRequestPlaceholders(pr, colorize) {
-		placeholders[key] = value
+type mergeBlocker struct {
+	Reason   string
+	ExitCode int
+}
+
+func checkMergePr(args *Args, project *github.Project, prNumber int) {
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would check whether pull request #%d for %s can be merged\n", prNumber, project)
+		return
 	}
-	return ui.Expand(format, placeholders, colorize)
+
+	gh := github.NewClient(project.Host)
+	pr, err := gh.PullRequest(project, strconv.Itoa(prNumber))
+	utils.Check(err)
+	if pr.State == "open" && pr.Mergeable == nil {
+		// GitHub computes mergeability in the background on first request
+		time.Sleep(2 * time.Second)
+		pr, err = gh.PullRequest(project, strconv.Itoa(prNumber))
+		utils.Check(err)
+	}
+
+	var protection *github.BranchProtection
+	var reviews []github.PullRequestReview
+	var statuses []github.CIStatus
+	protectionKnown := true
+	if pr.State == "open" {
+		protection, err = gh.FetchBranchProtection(project, pr.Base.Ref)
+		if err == github.ErrBranchProtectionUnavailable {
+			protectionKnown = false
+		} else {
+			utils.Check(err)
+		}
+
+		reviews, err = gh.FetchPullRequestReviews(project, prNumber)
+		utils.Check(err)
+
+		if protection != nil && protection.RequiredStatusChecks != nil && len(protection.RequiredStatusChecks.RequiredContexts()) > 0 {
+			response, err := gh.FetchCIStatus(project, pr.Head.Sha)
+			utils.Check(err)
+			statuses = response.Statuses
+		}
+	}
+
+	blockers := pullRequestMergeBlockers(pr, protection, protectionKnown, reviews, statuses)
+	if len(blockers) == 0 {
+		if !protectionKnown {
+			ui.Printf("Pull request #%d has no known blockers, but the branch protection rules of %s could not be read\n", prNumber, pr.Base.Ref)
+			os.Exit(mergeBlockersUnknown)
+		}
+		ui.Printf("Pull request #%d can be merged\n", prNumber)
+		return
+	}
+
+	ui.Printf("Pull request #%d cannot be merged:\n", prNumber)
+	exitCode := mergeBlockedByOther
+	for _, blocker := range blockers {
+		ui.Printf("  - %s\n", blocker.Reason)
+		if blocker.ExitCode < exitCode {
+			exitCode = blocker.ExitCode
+		}
+	}
+	if !protectionKnown {
+		ui.Printf("The branch protection rules of %s could not be read, so there might be other blockers\n", pr.Base.Ref)
+	}
+	os.Exit(exitCode)
+}
+
+// pullRequestMergeBlockers lists everything that prevents a pull request from
+// being merged, starting with the most fundamental problem. When the branch
+// protection rules are not known, a merge that GitHub reports as blocked isn't
+// attributed to them.
+func pullRequestMergeBlockers(pr *github.PullRequest, protection *github.BranchProtection, protectionKnown bool, reviews []github.PullRequestReview, statuses []github.CIStatus) []mergeBlocker {
+	blockers := []mergeBlocker{}
+	block := func(exitCode int, reason string, a ...interface{}) {
+		blockers = append(blockers, mergeBlocker{Reason: fmt.Sprintf(reason, a...), ExitCode: exitCode})
+	}
+
+	if !pr.MergedAt.IsZero() {
+		block(mergeBlockedByOther, "the pull request was already merged")
+		return blockers
+	} else if pr.State != "open" {
+		block(mergeBlockedByOther, "the pull request is closed")
+		return blockers
+	}
+
+	if pr.Mergeable == nil {
+		block(mergeBlockedByOther, "GitHub has not finished checking mergeability yet; try again shortly")
+	} else if !*pr.Mergeable || pr.MergeableState == "dirty" {
+		block(mergeBlockedByConflicts, "there are merge conflicts with %s", pr.Base.Ref)
+	}
+
+	latestReviews := map[string]string{}
+	reviewers := []string{}
+	for _, review := range reviews {
+		if review.User == nil || (review.State != "APPROVED" && review.State != "CHANGES_REQUESTED" && review.State != "DISMISSED") {
+			continue
+		}
+		if _, seen := latestReviews[review.User.Login]; !seen {
+			reviewers = append(reviewers, review.User.Login)
+		}
+		latestReviews[review.User.Login] = review.State
+	}
+	approvals := 0
+	changesRequested := []string{}
+	for _, reviewer := range reviewers {
+		switch latestReviews[reviewer] {
+		case "APPROVED":
+			approvals++
+		case "CHANGES_REQUESTED":
+			changesRequested = append(changesRequested, reviewer)
+		}
+	}
+	if len(changesRequested) > 0 {
+		block(mergeBlockedByReviews, "changes were requested by %s", strings.Join(changesRequested, ", "))
+	}
+	if protection != nil && protection.RequiredPullRequestReviews != nil {
+		if required := protection.RequiredPullRequestReviews.RequiredApprovingReviewCount; approvals < required {
+			block(mergeBlockedByReviews, "%d of %d required approving reviews", approvals, required)
+		}
+	}
+
+	if protection != nil && protection.RequiredStatusChecks != nil {
+		for _, context := range protection.RequiredStatusChecks.RequiredContexts() {
+			state := "expected"
+			for _, status := range statuses {
+				if status.Context == context {
+					state = status.State
+				}
+			}
+			switch state {
+			case "success", "neutral", "skipped":
+			case "expected", "pending":
+				block(mergeBlockedByChecks, "required check '%s' is %s", context, state)
+			default:
+				block(mergeBlockedByChecks, "required check '%s' has failed", context)
+			}
+		}
+	}
+
+	requiresUpToDate := protection != nil && protection.RequiredStatusChecks != nil && protection.RequiredStatusChecks.Strict
+	if pr.MergeableState == "behind" && requiresUpToDate {
+		block(mergeBlockedByOutdatedBranch, "the head branch is not up to date with %s", pr.Base.Ref)
+	}
+
+	if pr.Draft {
+		block(mergeBlockedByOther, "the pull request is a draft")
+	}
+
+	if len(blockers) == 0 && pr.MergeableState == "blocked" && protectionKnown {
+		block(mergeBlockedByOther, "merging is blocked by the branch protection rules of %s", pr.Base.Ref)
+	}
+
+	return blockers
+}
+
+func setPullRequestAutoMerge(args *Args, project *github.Project, prNumber int, params map[string]interface{}) {
+	gh := github.NewClient(project.Host)
+
+	query := `
+	query($owner: String!, $name: String!, $number: Int!) {
+		repository(owner: $owner, name: $name) {
+			pullRequest(number: $number) {
+				id
+				url
+				isMergeQueueEnabled
+				isInMergeQueue
+			}
+		}
+	}`
+	result := struct {
+		Repository struct {
+			PullRequest struct {
+				ID                  string
+				URL                 string
+				IsMergeQueueEnabled bool
+				IsInMergeQueue      bool
+			}
+		}
+	}{}
+	err := gh.GraphQL(query, map[string]interface{}{
+		"owner":  project.Owner,
+		"name":   project.Name,
+		"number": prNumber,
+	}, &result)
+	utils.Check(err)
+	pr := result.Repository.PullRequest
+
+	args.NoForward()
+
+	variables := map[string]interface{}{
+		"id": pr.ID,
+	}
+	var mutation, action string
+	if args.Flag.Bool("--auto") && pr.IsMergeQueueEnabled {
+		if hasField(args, "--squash", "--rebase", "--message", "--file") {
+			utils.Check(fmt.Errorf("Error: the base branch of pull request #%d uses a merge queue, which decides how pull requests get merged", prNumber))
+		}
+		mutation = `
+	mutation($id: ID!, $sha: GitObjectID) {
+		enqueuePullRequest(input: {pullRequestId: $id, expectedHeadOid: $sha}) {
+			clientMutationId
+		}
+	}`
+		action = fmt.Sprintf("add pull request #%d to the merge queue", prNumber)
+		if sha, ok := params["sha"]; ok {
+			variables["sha"] = sha
+		}
+	} else if args.Flag.Bool("--auto") {
+		mutation = `
+	mutation($id: ID!, $method: PullRequestMergeMethod, $headline: String, $body: String, $sha: GitObjectID) {
+		enablePullRequestAutoMerge(input: {pullRequestId: $id, mergeMethod: $method, commitHeadline: $headline, commitBody: $body, expectedHeadOid: $sha}) {
+			clientMutationId
+		}
+	}`
+		action = fmt.Sprintf("enable auto-merge for pull request #%d", prNumber)
+		variables["method"] = strings.ToUpper(params["merge_method"].(string))
+		if title, ok := params["commit_title"]; ok {
+			variables["headline"] = title
+			variables["body"] = params["commit_message"]
+		}
+		if sha, ok := params["sha"]; ok {
+			variables["sha"] = sha
+		}
+	} else if pr.IsInMergeQueue {
+		mutation = `
+	mutation($id: ID!) {
+		dequeuePullRequest(input: {id: $id}) {
+			clientMutationId
+		}
+	}`
+		action = fmt.Sprintf("remove pull request #%d from the merge queue", prNumber)
+	} else {
+		mutation = `
+	mutation($id: ID!) {
+		disablePullRequestAutoMerge(input: {pullRequestId: $id}) {
+			clientMutationId
+		}
+	}`
+		action = fmt.Sprintf("disable auto-merge for pull request #%d", prNumber)
+	}
+
+	if args.Noop {
+		ui.Printf("Would %s for %s\n", action, project)
+		return
+	}
+
+	err = gh.GraphQL(mutation, variables, &struct{}{})
+	utils.Check(err)
+
+	ui.Println(pr.URL)
+}
+
+func editPr(command *Command, args *Args) {
+	words := args.Words()
+	if len(words) == 0 {
+		utils.Check(fmt.Errorf("Error: No pull request number given"))
+	}
+
+	prNumber, err := strconv.Atoi(words[0])
+	utils.Check(err)
+
+	if !hasField(args, "--message", "--file", "--edit", "--base", "--reviewer", "--assign", "--milestone", "--labels") {
+		utils.Check(command.UsageError("please specify fields to update"))
+	}
+
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	gh := github.NewClient(project.Host)
+
+	var pr *github.PullRequest
+	prParams := map[string]interface{}{}
+	if args.Flag.HasReceived("--base") {
+		prParams["base"] = args.Flag.Value("--base")
+	}
+
+	if hasField(args, "--message", "--file", "--edit") {
+		messageBuilder := &github.MessageBuilder{
+			Filename: "PULLREQ_EDITMSG",
+			Title:    "pull request",
+		}
+
+		messageBuilder.AddCommentedSection(fmt.Sprintf(`Editing pull request #%d for %s
+
+Update the message for this pull request. The first block
+of text is the title and the rest is the description.`, prNumber, project))
+
+		messageBuilder.Edit = args.Flag.Bool("--edit")
+		flagPullRequestMessage := args.Flag.AllValues("--message")
+		if len(flagPullRequestMessage) > 0 {
+			messageBuilder.Message = strings.Join(flagPullRequestMessage, "\n\n")
+		} else if args.Flag.HasReceived("--file") {
+			messageBuilder.Message, err = msgFromFile(args.Flag.Value("--file"))
+			utils.Check(err)
+		} else {
+			pr, err = gh.PullRequest(project, strconv.Itoa(prNumber))
+			utils.Check(err)
+			existingMessage := fmt.Sprintf("%s\n\n%s", pr.Title, pr.Body)
+			messageBuilder.Message = strings.Replace(existingMessage, "\r\n", "\n", -1)
+		}
+
+		title, body, err := messageBuilder.Extract()
+		utils.Check(err)
+		if title == "" {
+			utils.Check(fmt.Errorf("Aborting due to empty pull request title"))
+		}
+		prParams["title"] = title
+		prParams["body"] = body
+		defer messageBuilder.Cleanup()
+	}
+
+	issueParams := map[string]interface{}{}
+	setLabelsFromArgs(issueParams, args)
+	setAssigneesFromArgs(issueParams, args)
+	setMilestoneFromArgs(issueParams, args, gh, project)
+
+	reviewers := commaSeparated(args.Flag.AllValues("--reviewer"))
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would update pull request #%d for %s\n", prNumber, project)
+		return
+	}
+
+	if len(prParams) > 0 {
+		pr, err = gh.UpdatePullRequest(project, prNumber, prParams)
+		utils.Check(err)
+	}
+
+	if len(issueParams) > 0 {
+		err = gh.UpdateIssue(project, prNumber, issueParams)
+		utils.Check(err)
+	}
+
+	if len(reviewers) > 0 {
+		if pr == nil {
+			pr, err = gh.PullRequest(project, strconv.Itoa(prNumber))
+			utils.Check(err)
+		}
+		err = requestReviewers(gh, project, pr, reviewers)
+		utils.Check(err)
+	}
+}
+
+func stackPr(command *Command, args *Args) {
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	currentBranch, err := localRepo.CurrentBranch()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	remote, err := localRepo.RemoteForProject(project)
+	utils.Check(err)
+
+	branches, err := git.LocalBranches()
+	utils.Check(err)
+
+	isLocal := map[string]bool{}
+	for _, branch := range branches {
+		isLocal[branch] = true
+	}
+	parents := map[string]string{}
+	for _, branch := range branches {
+		if branchRemote, _ := git.Config(fmt.Sprintf("branch.%s.remote", branch)); branchRemote != "." {
+			continue
+		}
+		branchMerge, _ := git.Config(fmt.Sprintf("branch.%s.merge", branch))
+		parent := strings.TrimPrefix(branchMerge, "refs/heads/")
+		if parent != branch && isLocal[parent] {
+			parents[branch] = parent
+		}
+	}
+
+	stack := stackBranches(currentBranch.ShortName(), parents)
+	if len(stack) == 0 {
+		utils.Check(fmt.Errorf("Error: branch '%s' is not part of a stack of local branches", currentBranch.ShortName()))
+	}
+
+	args.NoForward()
+	gh := github.NewClient(project.Host)
+
+	// bases of stacked branches whose pull requests were already merged
+	mergedInto := map[string]string{}
+	if bottom := parents[stack[0]]; !args.Noop {
+		if pr := findStackPullRequest(gh, project, bottom); pr != nil && !pr.MergedAt.IsZero() {
+			mergedInto[bottom] = pr.Base.Ref
+		}
+	}
+	openPulls := map[string]*github.PullRequest{}
+
+	for _, branch := range stack {
+		base := parents[branch]
+		if mergedBase, ok := mergedInto[base]; ok {
+			base = mergedBase
+		}
+
+		if args.Flag.Bool("--push") {
+			if args.Noop {
+				ui.Printf("Would push %s to %s\n", branch, remote.Name)
+			} else {
+				err = git.Spawn("push", remote.Name, fmt.Sprintf("refs/heads/%s:refs/heads/%s", branch, branch))
+				utils.Check(err)
+			}
+		}
+
+		if args.Noop {
+			ui.Printf("Would open or update pull request for %s -> %s\n", branch, base)
+			continue
+		}
+
+		pr := findStackPullRequest(gh, project, branch)
+		if pr != nil && pr.State == "open" {
+			if pr.Base.Ref == base {
+				ui.Printf("unchanged #%d %s -> %s\n", pr.Number, branch, base)
+			} else {
+				pr, err = gh.UpdatePullRequest(project, pr.Number, map[string]interface{}{"base": base})
+				utils.Check(err)
+				ui.Printf("retargeted #%d %s -> %s\n", pr.Number, branch, base)
+			}
+			openPulls[branch] = pr
+			continue
+		} else if pr != nil && !pr.MergedAt.IsZero() {
+			mergedInto[branch] = base
+			ui.Printf("merged #%d %s -> %s\n", pr.Number, branch, pr.Base.Ref)
+			continue
+		} else if pr != nil {
+			ui.Printf("skipped #%d %s -> %s (closed)\n", pr.Number, branch, base)
+			continue
+		}
+
+		commits, err := git.RefList(base, branch)
+		utils.Check(err)
+		if len(commits) == 0 {
+			ui.Printf("skipped %s -> %s (no commits)\n", branch, base)
+			continue
+		}
+
+		title, body, err := stackPullRequestMessage(commits)
+		utils.Check(err)
+		if basePr := openPulls[base]; basePr != nil {
+			body = strings.TrimSpace(fmt.Sprintf("%s\n\nDepends on #%d", body, basePr.Number))
+		}
+
+		params := map[string]interface{}{
+			"base":  base,
+			"head":  fmt.Sprintf("%s:%s", project.Owner, branch),
+			"title": title,
+			"body":  body,
+		}
+		if args.Flag.Bool("--draft") {
+			params["draft"] = true
+		}
+
+		pr, err = gh.CreatePullRequest(project, params)
+		utils.Check(err)
+		openPulls[branch] = pr
+		ui.Printf("created #%d %s -> %s\n", pr.Number, branch, base)
+	}
+}
+
+// stackBranches returns the branches of the stack that the given branch belongs
+// to, ordered so that every branch comes after the branch it is based on
+func stackBranches(branch string, parents map[string]string) []string {
+	children := map[string][]string{}
+	for child, parent := range parents {
+		children[parent] = append(children[parent], child)
+	}
+
+	root := branch
+	seen := map[string]bool{root: true}
+	for {
+		parent, ok := parents[root]
+		if !ok || seen[parent] {
+			break
+		}
+		seen[parent] = true
+		root = parent
+	}
+
+	stack := []string{}
+	queue := children[root]
+	sort.Strings(queue)
+	visited := map[string]bool{}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if visited[current] {
+			continue
+		}
+		visited[current] = true
+		stack = append(stack, current)
+
+		next := children[current]
+		sort.Strings(next)
+		queue = append(queue, next...)
+	}
+
+	return stack
+}
+
+func findStackPullRequest(gh *github.Client, project *github.Project, branch string) *github.PullRequest {
+	filters := map[string]interface{}{
+		"head":  fmt.Sprintf("%s:%s", project.Owner, branch),
+		"state": "all",
+	}
+	pulls, err := gh.FetchPullRequests(project, filters, 0, nil)
+	utils.Check(err)
+
+	for i, pr := range pulls {
+		if pr.State == "open" {
+			return &pulls[i]
+		}
+	}
+	if len(pulls) > 0 {
+		return &pulls[0]
+	}
+	return nil
+}
+
+func stackPullRequestMessage(commits []string) (title, body string, err error) {
+	message, err := git.Show(commits[len(commits)-1])
+	if err != nil {
+		return
+	}
+	if len(commits) == 1 {
+		title, body = github.SplitTitleBody(message)
+		return
+	}
+
+	title, _ = github.SplitTitleBody(message)
+	subjects := []string{}
+	for i := len(commits) - 1; i >= 0; i-- {
+		message, err = git.Show(commits[i])
+		if err != nil {
+			return
+		}
+		subject, _ := github.SplitTitleBody(message)
+		subjects = append(subjects, "- "+subject)
+	}
+	body = strings.Join(subjects, "\n")
+	return
+}
+
+func updateBranchPr(command *Command, args *Args) {
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would update the head branch of a pull request for %s\n", project)
+		return
+	}
+
+	gh := github.NewClient(project.Host)
+	pr, err := findPullRequest(localRepo, gh, project, args.Words(), "open")
+	utils.Check(err)
+
+	newHeadSha := ""
+	if args.Flag.Bool("--rebase") {
+		mutation := `
+	mutation($id: ID!, $sha: GitObjectID) {
+		updatePullRequestBranch(input: {pullRequestId: $id, expectedHeadOid: $sha, updateMethod: REBASE}) {
+			pullRequest { headRefOid }
+		}
+	}`
+		variables := map[string]interface{}{
+			"id":  pr.NodeID,
+			"sha": pr.Head.Sha,
+		}
+		result := struct {
+			UpdatePullRequestBranch struct {
+				PullRequest struct {
+					HeadRefOid string
+				}
+			}
+		}{}
+		err = gh.GraphQL(mutation, variables, &result)
+		utils.Check(err)
+		newHeadSha = result.UpdatePullRequestBranch.PullRequest.HeadRefOid
+		ui.Printf("Rebased %s onto %s for pull request #%d\n", pr.Head.Ref, pr.Base.Ref, pr.Number)
+	} else {
+		err = gh.UpdatePullRequestBranch(project, pr.Number, pr.Head.Sha)
+		utils.Check(err)
+		ui.Printf("Merging %s into %s for pull request #%d\n", pr.Base.Ref, pr.Head.Ref, pr.Number)
+	}
+
+	if !args.Flag.Bool("--fast-forward") {
+		return
+	}
+
+	currentBranch, err := localRepo.CurrentBranch()
+	if err != nil {
+		return
+	}
+	branchRemote, trackingBranch, err := branchTrackingInformation(currentBranch)
+	isCheckedOut := err == nil && trackingBranch.ShortName() == pr.Head.Ref && pr.Head.Repo != nil
+	if isCheckedOut {
+		// a branch of the same name might be tracked from a different fork
+		headRemote, err := localRepo.RemoteForRepo(pr.Head.Repo)
+		isCheckedOut = err == nil && headRemote.Name == branchRemote
+	}
+	if !isCheckedOut {
+		ui.Errorf("Skipping fast-forward: %s is not checked out\n", pr.Head.Ref)
+		return
+	}
+
+	// the rebase rewrites the head branch, so the local branch can only be moved
+	// along with it if it had nothing else than the old head
+	rebase := args.Flag.Bool("--rebase")
+	if rebase {
+		if localHead, err := git.Ref("HEAD"); err != nil || localHead != pr.Head.Sha {
+			ui.Errorf("Skipping fast-forward: the local %s branch does not match the head of pull request #%d before the rebase\n", currentBranch.ShortName(), pr.Number)
+			return
+		}
+	}
+
+	// merging happens in the background, so wait for the head to move
+	for i := 0; newHeadSha == "" || newHeadSha == pr.Head.Sha; i++ {
+		if i == 10 {
+			utils.Check(fmt.Errorf("Error: timed out waiting for the head of pull request #%d to be updated", pr.Number))
+		}
+		time.Sleep(time.Second)
+		updatedPr, err := gh.PullRequest(project, strconv.Itoa(pr.Number))
+		utils.Check(err)
+		newHeadSha = updatedPr.Head.Sha
+	}
+
+	err = git.Spawn("fetch", branchRemote, trackingBranch.ShortName())
+	utils.Check(err)
+	remoteRef := fmt.Sprintf("refs/remotes/%s/%s", branchRemote, trackingBranch.ShortName())
+	if rebase {
+		err = git.Spawn("reset", "--keep", remoteRef)
+	} else {
+		err = git.Spawn("merge", "--ff-only", remoteRef)
+	}
+	utils.Check(err)
+}
+
+func commentPr(command *Command, args *Args) {
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	gh := github.NewClient(project.Host)
+	pr, err := findPullRequest(localRepo, gh, project, args.Words(), "open")
+	utils.Check(err)
+
+	postComment(command, args, gh, project, pr.Number)
+}
+
+func checksPr(command *Command, args *Args) {
+	interval := 10
+	if args.Flag.HasReceived("--interval") {
+		var err error
+		interval, err = strconv.Atoi(args.Flag.Value("--interval"))
+		if err != nil || interval < 1 {
+			utils.Check(command.UsageError(fmt.Sprintf("invalid interval: '%s'", args.Flag.Value("--interval"))))
+		}
+	}
+
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	gh := github.NewClient(project.Host)
+	pr, err := findPullRequest(localRepo, gh, project, args.Words(), "open")
+	utils.Check(err)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would request CI status for pull request #%d for %s\n", pr.Number, project)
+		return
+	}
+
+	watch := args.Flag.Bool("--watch")
+	failFast := args.Flag.Bool("--fail-fast")
+	redraw := watch && ui.IsTerminal(os.Stdout)
+	colorize := colorizeOutput(args.Flag.HasReceived("--color"), args.Flag.Value("--color"))
+
+	state := ""
+	for polls := 0; ; polls++ {
+		if polls > 0 {
+			time.Sleep(time.Duration(interval) * time.Second)
+		}
+
+		response, err := gh.FetchCIStatus(project, pr.Head.Sha)
+		utils.Check(err)
+		state = ciStatusState(response.Statuses)
+
+		if redraw {
+			ui.Print("\033[H\033[2J")
+			ui.Printf("Refreshing checks for #%d every %d seconds. Press Ctrl+C to quit.\n\n", pr.Number, interval)
+		} else if polls > 0 {
+			ui.Println()
+		}
+
+		if len(response.Statuses) > 0 {
+			ciVerboseFormat(response.Statuses, args.Flag.Value("--format"), colorize)
+		} else {
+			ui.Println("no status")
+		}
+
+		if !watch {
+			break
+		}
+		if len(response.Statuses) == 0 {
+			// checks might not have been reported yet for a fresh push
+			if polls+1 >= checksWatchEmptyPolls {
+				break
+			}
+			continue
+		}
+		if !hasPendingCheck(response.Statuses) {
+			break
+		}
+		if failFast && ciStatusExitCode(state) == 1 {
+			break
+		}
+	}
+
+	os.Exit(ciStatusExitCode(state))
+}
+
+// how many times to poll in `pr checks --watch` before giving up on checks
+// ever getting reported
+const checksWatchEmptyPolls = 6
+
+func hasPendingCheck(statuses []github.CIStatus) bool {
+	for _, status := range statuses {
+		if status.State == "pending" {
+			return true
+		}
+	}
+	return false
+}
+
+func closePr(command *Command, args *Args) {
+	setPullRequestState(args, "closed")
+}
+
+func reopenPr(command *Command, args *Args) {
+	setPullRequestState(args, "open")
+}
+
+func setPullRequestState(args *Args, state string) {
+	comment := ""
+	if msgs := args.Flag.AllValues("--message"); len(msgs) > 0 {
+		comment = strings.Join(msgs, "\n\n")
+	} else if args.Flag.HasReceived("--file") {
+		content, err := msgFromFile(args.Flag.Value("--file"))
+		utils.Check(err)
+		comment = strings.TrimSpace(content)
+	}
+
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	currentState := "open"
+	if state == "open" {
+		currentState = "closed"
+	}
+
+	gh := github.NewClient(project.Host)
+	pr, err := findPullRequest(localRepo, gh, project, args.Words(), currentState)
+	utils.Check(err)
+
+	action := "close"
+	if state == "open" {
+		action = "reopen"
+	}
+
+	if !pr.MergedAt.IsZero() {
+		utils.Check(fmt.Errorf("Error: pull request #%d is already merged", pr.Number))
+	}
+
+	args.NoForward()
+	if pr.State == state {
+		ui.Errorf("Pull request #%d is already %s\n", pr.Number, state)
+		return
+	}
+
+	if args.Noop {
+		ui.Printf("Would %s pull request #%d for %s\n", action, pr.Number, project)
+		return
+	}
+
+	if comment != "" {
+		_, err = gh.CreateIssueComment(project, pr.Number, comment)
+		utils.Check(err)
+	}
+
+	_, err = gh.UpdatePullRequest(project, pr.Number, map[string]interface{}{
+		"state": state,
+	})
+	utils.Check(err)
+
+	if state == "closed" && args.Flag.Bool("--delete-branch") && pr.IsSameRepo() {
+		err = gh.DeleteBranch(project, pr.Head.Ref)
+		utils.Check(err)
+	}
+}
+
+func readyPr(command *Command, args *Args) {
+	setPullRequestDraft(args, false)
+}
+
+func draftPr(command *Command, args *Args) {
+	setPullRequestDraft(args, true)
+}
+
+func setPullRequestDraft(args *Args, draft bool) {
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	gh := github.NewClient(project.Host)
+	pr, err := findPullRequest(localRepo, gh, project, args.Words(), "open")
+	utils.Check(err)
+
+	args.NoForward()
+	if pr.Draft == draft {
+		if draft {
+			ui.Errorf("Pull request #%d is already a draft\n", pr.Number)
+		} else {
+			ui.Errorf("Pull request #%d is already ready for review\n", pr.Number)
+		}
+		return
+	}
+
+	mutation := `
+	mutation($id: ID!) {
+		markPullRequestReadyForReview(input: {pullRequestId: $id}) {
+			pullRequest {
+				isDraft
+			}
+		}
+	}`
+	action := fmt.Sprintf("mark pull request #%d as ready for review", pr.Number)
+	if draft {
+		mutation = `
+	mutation($id: ID!) {
+		convertPullRequestToDraft(input: {pullRequestId: $id}) {
+			pullRequest {
+				isDraft
+			}
+		}
+	}`
+		action = fmt.Sprintf("convert pull request #%d to draft", pr.Number)
+	}
+
+	if args.Noop {
+		ui.Printf("Would %s for %s\n", action, project)
+		return
+	}
+
+	err = gh.GraphQL(mutation, map[string]interface{}{
+		"id": pr.NodeID,
+	}, &struct{}{})
+	utils.Check(err)
+
+	ui.Println(pr.HTMLURL)
+}
+
+func diffPr(command *Command, args *Args) {
+	words := args.Words()
+	if len(words) == 0 {
+		utils.Check(fmt.Errorf("Error: No pull request number given"))
+	}
+
+	prNumber, err := strconv.Atoi(words[0])
+	utils.Check(err)
+
+	if args.Flag.Bool("--name-only") && args.Flag.Bool("--stat") {
+		utils.Check(command.UsageError("--name-only and --stat cannot be used together"))
+	}
+
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would request diff of pull request #%d for %s\n", prNumber, project)
+		return
+	}
+
+	gh := github.NewClient(project.Host)
+	res, err := gh.PullRequestDiff(project, strconv.Itoa(prNumber))
+	utils.Check(err)
+	defer res.Close()
+
+	content, err := ioutil.ReadAll(res)
+	utils.Check(err)
+	diff := strings.Replace(string(content), "\r\n", "\n", -1)
+
+	colorize := colorizeOutput(args.Flag.HasReceived("--color"), args.Flag.Value("--color"))
+	output := diff
+	if args.Flag.Bool("--name-only") {
+		output = ""
+		for _, file := range parseDiffStat(diff) {
+			output += file.Name + "\n"
+		}
+	} else if args.Flag.Bool("--stat") {
+		output = formatDiffStat(parseDiffStat(diff), colorize)
+	} else if colorize {
+		output = colorizeDiff(diff)
+	}
+
+	err = printWithPager(output)
+	utils.Check(err)
+}
+
+type diffFileStat struct {
+	Name      string
+	Additions int
+	Deletions int
+	Binary    bool
+}
+
+func parseDiffStat(diff string) []diffFileStat {
+	files := []diffFileStat{}
+	var file *diffFileStat
+	inHunk := false
+
+	for _, line := range strings.Split(diff, "\n") {
+		if strings.HasPrefix(line, "diff --git ") {
+			name := line
+			if idx := strings.LastIndex(line, " b/"); idx >= 0 {
+				name = line[idx+3:]
+			}
+			files = append(files, diffFileStat{Name: name})
+			file = &files[len(files)-1]
+			inHunk = false
+		} else if file == nil {
+			continue
+		} else if strings.HasPrefix(line, "@@") {
+			inHunk = true
+		} else if !inHunk {
+			if strings.HasPrefix(line, "Binary files ") {
+				file.Binary = true
+			}
+		} else if strings.HasPrefix(line, "+") {
+			file.Additions++
+		} else if strings.HasPrefix(line, "-") {
+			file.Deletions++
+		}
+	}
+
+	return files
+}
+
+func formatDiffStat(files []diffFileStat, colorize bool) string {
+	nameWidth := 0
+	maxChanges := 0
+	totalAdditions := 0
+	totalDeletions := 0
+	for _, file := range files {
+		if len(file.Name) > nameWidth {
+			nameWidth = len(file.Name)
+		}
+		if changes := file.Additions + file.Deletions; changes > maxChanges {
+			maxChanges = changes
+		}
+		totalAdditions += file.Additions
+		totalDeletions += file.Deletions
+	}
+
+	countWidth := len(strconv.Itoa(maxChanges))
+	if countWidth < 3 && hasBinaryFile(files) {
+		countWidth = 3
+	}
+	graphWidth := 80 - nameWidth - countWidth - 5
+	if graphWidth < 10 {
+		graphWidth = 10
+	}
+
+	scale := func(n int) int {
+		if maxChanges <= graphWidth || n == 0 {
+			return n
+		}
+		scaled := n * graphWidth / maxChanges
+		if scaled == 0 {
+			scaled = 1
+		}
+		return scaled
+	}
+
+	plusColor, minusColor, resetColor := "", "", ""
+	if colorize {
+		plusColor, minusColor, resetColor = "\033[32m", "\033[31m", "\033[m"
+	}
+
+	output := ""
+	for _, file := range files {
+		if file.Binary {
+			output += fmt.Sprintf(" %-*s | %*s\n", nameWidth, file.Name, countWidth, "Bin")
+			continue
+		}
+		graph := ""
+		if additions := scale(file.Additions); additions > 0 {
+			graph += plusColor + strings.Repeat("+", additions) + resetColor
+		}
+		if deletions := scale(file.Deletions); deletions > 0 {
+			graph += minusColor + strings.Repeat("-", deletions) + resetColor
+		}
+		output += fmt.Sprintf(" %-*s | %*d %s\n", nameWidth, file.Name, countWidth, file.Additions+file.Deletions, graph)
+	}
+
+	summary := fmt.Sprintf(" %d %s changed", len(files), pluralize(len(files), "file"))
+	if totalAdditions > 0 || totalDeletions == 0 {
+		summary += fmt.Sprintf(", %d %s(+)", totalAdditions, pluralize(totalAdditions, "insertion"))
+	}
+	if totalDeletions > 0 || totalAdditions == 0 {
+		summary += fmt.Sprintf(", %d %s(-)", totalDeletions, pluralize(totalDeletions, "deletion"))
+	}
+
+	return output + summary + "\n"
+}
+
+func hasBinaryFile(files []diffFileStat) bool {
+	for _, file := range files {
+		if file.Binary {
+			return true
+		}
+	}
+	return false
+}
+
+func colorizeDiff(diff string) string {
+	lines := strings.Split(diff, "\n")
+	inHunk := false
+	for i, line := range lines {
+		color := ""
+		if strings.HasPrefix(line, "diff --git ") {
+			inHunk = false
+			color = "\033[1m"
+		} else if strings.HasPrefix(line, "@@") {
+			inHunk = true
+			color = "\033[36m"
+		} else if !inHunk {
+			if line != "" {
+				color = "\033[1m"
+			}
+		} else if strings.HasPrefix(line, "+") {
+			color = "\033[32m"
+		} else if strings.HasPrefix(line, "-") {
+			color = "\033[31m"
+		}
+		if color != "" {
+			lines[i] = color + line + "\033[m"
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func revertPr(command *Command, args *Args) {
+	words := args.Words()
+	if len(words) == 0 {
+		utils.Check(fmt.Errorf("Error: No pull request number given"))
+	}
+
+	prNumber, err := strconv.Atoi(words[0])
+	utils.Check(err)
+
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	remote, err := localRepo.RemoteForProject(project)
+	utils.Check(err)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would revert pull request #%d for %s\n", prNumber, project)
+		return
+	}
+
+	gh := github.NewClient(project.Host)
+	pr, err := gh.PullRequest(project, strconv.Itoa(prNumber))
+	utils.Check(err)
+
+	if pr.MergedAt.IsZero() || pr.MergeCommitSha == "" {
+		utils.Check(fmt.Errorf("Error: pull request #%d has not been merged", prNumber))
+	}
+
+	base := pr.Base.Ref
+	branch := fmt.Sprintf("revert-%d-%s", prNumber, pr.Head.Ref)
+	if _, err := git.Ref("refs/heads/" + branch); err == nil {
+		utils.Check(fmt.Errorf("Error: branch '%s' already exists", branch))
+	}
+
+	err = git.Spawn("fetch", remote.Name, base)
+	utils.Check(err)
+
+	_, err = git.Ref(pr.MergeCommitSha + "^2")
+	isMergeCommit := err == nil
+
+	// compare the changes of as many commits on the base branch as there are in
+	// the pull request to the pull request commits to detect a rebase merge
+	var cherries []string
+	if !isMergeCommit && pr.Commits > 1 {
+		err = git.Spawn("fetch", remote.Name, fmt.Sprintf("refs/pull/%d/head", prNumber))
+		utils.Check(err)
+		cherries, err = git.Cherry(pr.Head.Sha, pr.MergeCommitSha, fmt.Sprintf("%s~%d", pr.MergeCommitSha, pr.Commits))
+		if err != nil {
+			utils.Check(fmt.Errorf("Error: could not compare the commits of pull request #%d to %s", prNumber, base))
+		}
+	}
+
+	commitArgs, err := revertCommitArgs(pr.MergeCommitSha, isMergeCommit, pr.Commits, cherries)
+	if err != nil {
+		utils.Check(fmt.Errorf("Error: could not tell how pull request #%d was merged: %s\nRevert its commits manually.", prNumber, err))
+	}
+
+	err = git.Spawn("checkout", "-b", branch, "--no-track", fmt.Sprintf("%s/%s", remote.Name, base))
+	utils.Check(err)
+
+	revertArgs := append([]string{"revert", "--no-edit"}, commitArgs...)
+	if err = git.Spawn(revertArgs...); err != nil {
+		utils.Check(fmt.Errorf("Error: could not revert pull request #%d cleanly\nResolve the conflicts, then run `git revert --continue`, push the '%s' branch and open a pull request for it.", prNumber, branch))
+	}
+
+	err = git.Spawn("push", "--set-upstream", remote.Name, fmt.Sprintf("HEAD:refs/heads/%s", branch))
+	utils.Check(err)
+
+	params := map[string]interface{}{
+		"base":  base,
+		"head":  fmt.Sprintf("%s:%s", project.Owner, branch),
+		"title": fmt.Sprintf("Revert \"%s\"", pr.Title),
+		"body":  fmt.Sprintf("Reverts #%d", prNumber),
+	}
+	if args.Flag.Bool("--draft") {
+		params["draft"] = true
+	}
+
+	revertPull, err := gh.CreatePullRequest(project, params)
+	utils.Check(err)
+	ui.Println(revertPull.HTMLURL)
+}
+
+// revertCommitArgs returns the arguments to `git revert` that undo a pull
+// request given its merge commit SHA. A pull request that was rebased consists
+// of multiple commits on the base branch, which is only assumed when each of
+// the commits leading up to the merge SHA has an equivalent commit in the pull
+// request, as reported by `git cherry`. When none of them do, the pull request
+// was squashed and only the merge SHA is reverted. Anything in between is an
+// error rather than a guess.
+func revertCommitArgs(mergeSha string, isMergeCommit bool, prCommits int, cherries []string) ([]string, error) {
+	if isMergeCommit {
+		return []string{"-m", "1", mergeSha}, nil
+	}
+	if prCommits <= 1 {
+		return []string{mergeSha}, nil
+	}
+
+	matches := 0
+	for _, cherry := range cherries {
+		if strings.HasPrefix(cherry, "-") {
+			matches++
+		}
+	}
+	if matches == 0 {
+		return []string{mergeSha}, nil
+	} else if matches == prCommits && len(cherries) == prCommits {
+		return []string{fmt.Sprintf("%s~%d..%s", mergeSha, prCommits, mergeSha)}, nil
+	}
+	return nil, fmt.Errorf("%d of the %d commits leading up to %s match the pull request commits", matches, len(cherries), mergeSha)
+}
+
+func reviewPr(command *Command, args *Args) {
+	words := args.Words()
+	if len(words) == 0 {
+		utils.Check(fmt.Errorf("Error: No pull request number given"))
+	}
+
+	prNumber, err := strconv.Atoi(words[0])
+	utils.Check(err)
+
+	reviewEvents := []struct {
+		flag  string
+		event string
+	}{
+		{"--approve", "APPROVE"},
+		{"--request-changes", "REQUEST_CHANGES"},
+		{"--comment", "COMMENT"},
+	}
+
+	event := ""
+	for _, e := range reviewEvents {
+		if !args.Flag.Bool(e.flag) {
+			continue
+		}
+		if event != "" {
+			utils.Check(command.UsageError("only one of --approve, --request-changes, or --comment can be given"))
+		}
+		event = e.event
+	}
+	if event == "" {
+		utils.Check(command.UsageError("please specify --approve, --request-changes, or --comment"))
+	}
+
+	comments := []github.PullRequestReviewComment{}
+	for _, value := range args.Flag.AllValues("--inline") {
+		comment, err := parseReviewComment(value)
+		utils.Check(err)
+		comments = append(comments, comment)
+	}
+
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	messageBuilder := &github.MessageBuilder{
+		Filename: "PULLREQ_REVIEW_EDITMSG",
+		Title:    "pull request review",
+	}
+
+	messageBuilder.AddCommentedSection(fmt.Sprintf(`Reviewing pull request #%d for %s
+
+Write a message for this review in Markdown format.`, prNumber, project))
+
+	flagReviewEdit := args.Flag.Bool("--edit")
+	flagReviewMessage := args.Flag.AllValues("--message")
+	if len(flagReviewMessage) > 0 {
+		messageBuilder.Message = strings.Join(flagReviewMessage, "\n\n")
+		messageBuilder.Edit = flagReviewEdit
+	} else if args.Flag.HasReceived("--file") {
+		messageBuilder.Message, err = msgFromFile(args.Flag.Value("--file"))
+		utils.Check(err)
+		messageBuilder.Edit = flagReviewEdit
+	} else {
+		messageBuilder.Edit = flagReviewEdit || event != "APPROVE"
+	}
+
+	body, err := messageBuilder.ExtractBody()
+	utils.Check(err)
+
+	if body == "" && event != "APPROVE" {
+		utils.Check(fmt.Errorf("Aborting review due to empty message"))
+	}
+
+	params := map[string]interface{}{
+		"event": event,
+	}
+	if body != "" {
+		params["body"] = body
+	}
+	if len(comments) > 0 {
+		params["comments"] = comments
+	}
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would submit review for pull request #%d for %s\n", prNumber, project)
+		return
+	}
+
+	gh := github.NewClient(project.Host)
+	review, err := gh.CreatePullRequestReview(project, prNumber, params)
+	utils.Check(err)
+
+	messageBuilder.Cleanup()
+	ui.Println(review.HTMLURL)
+}
+
+var reviewCommentRegexp = regexp.MustCompile(`^([^:]+):(-?)(\d+):(.+)$`)
+
+func parseReviewComment(value string) (comment github.PullRequestReviewComment, err error) {
+	parts := reviewCommentRegexp.FindStringSubmatch(value)
+	if parts == nil {
+		err = fmt.Errorf("invalid review comment: '%s'\n(use `--inline <PATH>:<LINE>:<TEXT>`)", value)
+		return
+	}
+
+	comment.Path = parts[1]
+	comment.Line, _ = strconv.Atoi(parts[3])
+	comment.Side = "RIGHT"
+	if parts[2] == "-" {
+		comment.Side = "LEFT"
+	}
+	comment.Body = parts[4]
+	return
+}
+
+func formatPullRequest(pr github.PullRequest, format string, colorize bool) string {
+	placeholders := pullRequestPlaceholders(pr, colorize)
+	return ui.Expand(format, placeholders, colorize)
+}
+
+var reviewPlaceholdersRegexp = regexp.MustCompile(`%r[act]`)
+
+// usesReviewPlaceholders reports whether a format needs review data, which
+// isn't part of the pull request payload and has to be fetched separately
+func usesReviewPlaceholders(format string) bool {
+	return reviewPlaceholdersRegexp.MatchString(format)
+}
+
+func formatPullRequestWithReviews(pr github.PullRequest, reviewData *github.PullRequestReviewData, format string, colorize bool) string {
+	placeholders := pullRequestPlaceholders(pr, colorize)
+
+	approvals := 0
+	changesRequested := []string{}
+	for _, review := range reviewData.Reviews {
+		switch review.State {
+		case "APPROVED":
+			approvals++
+		case "CHANGES_REQUESTED":
+			if review.User != nil {
+				changesRequested = append(changesRequested, review.User.Login)
+			}
+		}
+	}
+	placeholders["ra"] = strconv.Itoa(approvals)
+	placeholders["rc"] = strings.Join(changesRequested, ", ")
+	placeholders["rt"] = strconv.Itoa(reviewData.UnresolvedThreads())
+
+	return ui.Expand(format, placeholders, colorize)
+}
+
+func formatReviewThreads(threads []github.PullRequestReviewThread) string {
+	var output strings.Builder
+	for i, thread := range threads {
+		if i > 0 {
+			output.WriteString("\n")
+		}
+		output.WriteString(thread.Path)
+		if thread.Line > 0 {
+			fmt.Fprintf(&output, ":%d", thread.Line)
+		}
+		notes := []string{}
+		if thread.IsResolved {
+			notes = append(notes, "resolved")
+		}
+		if thread.IsOutdated {
+			notes = append(notes, "outdated")
+		}
+		if len(notes) > 0 {
+			fmt.Fprintf(&output, " (%s)", strings.Join(notes, ", "))
+		}
+		output.WriteString("\n")
+
+		for _, line := range diffHunkContext(thread.DiffHunk) {
+			output.WriteString(strings.TrimRight("  "+line, " ") + "\n")
+		}
+
+		for _, comment := range thread.Comments {
+			author := "ghost"
+			if comment.User != nil {
+				author = comment.User.Login
+			}
+			fmt.Fprintf(&output, "  %s:\n", author)
+			for _, line := range strings.Split(strings.TrimSpace(strings.Replace(comment.Body, "\r\n", "\n", -1)), "\n") {
+				output.WriteString(strings.TrimRight("    "+line, " ") + "\n")
+			}
+		}
+	}
+	return output.String()
+}
+
+// how many lines of the diff to show above review comments
+const reviewContextLines = 4
+
+// diffHunkContext returns the last lines of a diff hunk, which end with the
+// line that a review comment was made on
+func diffHunkContext(diffHunk string) []string {
+	lines := []string{}
+	for _, line := range strings.Split(strings.Replace(diffHunk, "\r\n", "\n", -1), "\n") {
+		if line != "" && !strings.HasPrefix(line, "@@") {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) > reviewContextLines {
+		lines = lines[len(lines)-reviewContextLines:]
+	}
+	return lines
+}
+
+func formatPullRequestStatus(pr github.PullRequest, ciState string, format string, colorize bool) string {
+	placeholders := pullRequestPlaceholders(pr, colorize)
+	placeholders["cS"] = ciState
+	placeholders["cC"] = ""
+	if colorize && ciState != "" {
+		ciColor := 33
+		switch stateRank(ciState) {
+		case 1:
+			ciColor = 31
+		case 3:
+			ciColor = 32
+		}
+		placeholders["cC"] = fmt.Sprintf("\033[%dm", ciColor)
+	}
+	return ui.Expand(format, placeholders, colorize)
+}
+
+func pullRequestPlaceholders(pr github.PullRequest, colorize bool) map[string]string {
+	placeholders := formatIssuePlaceholders(github.Issue(pr), colorize)
+	delete(placeholders, "NC")
+	delete(placeholders, "Nc")
+
+	for key, value := range formatPullRequestPlaceholders(pr, colorize) {
+		placeholders[key] = value
+	}
+	return placeholders
 }
@@ -0,0 +1,287 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/internal/assert"
+)
+
+func TestPr_ParseReviewComment(t *testing.T) {
+	comment, err := parseReviewComment("commands/pr.go:42:Why not use a constant here?")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "commands/pr.go", comment.Path)
+	assert.Equal(t, 42, comment.Line)
+	assert.Equal(t, "RIGHT", comment.Side)
+	assert.Equal(t, "Why not use a constant here?", comment.Body)
+
+	comment, err = parseReviewComment("README.md:7:see: the docs")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "README.md", comment.Path)
+	assert.Equal(t, 7, comment.Line)
+	assert.Equal(t, "see: the docs", comment.Body)
+
+	comment, err = parseReviewComment("main.go:-12:Why was this removed?")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "main.go", comment.Path)
+	assert.Equal(t, 12, comment.Line)
+	assert.Equal(t, "LEFT", comment.Side)
+	assert.Equal(t, "Why was this removed?", comment.Body)
+
+	_, err = parseReviewComment("README.md:seven:typo")
+	assert.NotEqual(t, nil, err)
+
+	_, err = parseReviewComment("README.md:7")
+	assert.NotEqual(t, nil, err)
+}
+
+const prTestDiff = `diff --git a/README.md b/README.md
+index 3d1f2a4..8c0e5b1 100644
+--- a/README.md
++++ b/README.md
+@@ -1,3 +1,4 @@
+ # hub
+-Old intro
++New intro
++-- with a dash
+ More text
+diff --git a/logo.png b/logo.png
+new file mode 100644
+index 0000000..e69de29
+Binary files /dev/null and b/logo.png differ
+diff --git a/main.go b/main.go
+index 1234567..89abcde 100644
+--- a/main.go
++++ b/main.go
+@@ -10,2 +10,0 @@ func main() {
+--- not a header
+-os.Exit(0)
+`
+
+func TestPr_ParseDiffStat(t *testing.T) {
+	files := parseDiffStat(prTestDiff)
+	assert.Equal(t, []diffFileStat{
+		{Name: "README.md", Additions: 2, Deletions: 1},
+		{Name: "logo.png", Binary: true},
+		{Name: "main.go", Deletions: 2},
+	}, files)
+}
+
+func TestPr_FormatDiffStat(t *testing.T) {
+	output := formatDiffStat(parseDiffStat(prTestDiff), false)
+	assert.Equal(t, ""+
+		" README.md |   3 ++-\n"+
+		" logo.png  | Bin\n"+
+		" main.go   |   2 --\n"+
+		" 3 files changed, 2 insertions(+), 3 deletions(-)\n", output)
+
+	output = formatDiffStat([]diffFileStat{{Name: "a.txt", Additions: 1}}, true)
+	assert.Equal(t, ""+
+		" a.txt | 1 \033[32m+\033[m\n"+
+		" 1 file changed, 1 insertion(+)\n", output)
+}
+
+func TestPr_ColorizeDiff(t *testing.T) {
+	diff := "diff --git a/a.txt b/a.txt\n--- a/a.txt\n+++ b/a.txt\n@@ -1 +1 @@\n-old\n+new\n context"
+	assert.Equal(t, ""+
+		"\033[1mdiff --git a/a.txt b/a.txt\033[m\n"+
+		"\033[1m--- a/a.txt\033[m\n"+
+		"\033[1m+++ b/a.txt\033[m\n"+
+		"\033[36m@@ -1 +1 @@\033[m\n"+
+		"\033[31m-old\033[m\n"+
+		"\033[32m+new\033[m\n"+
+		" context", colorizeDiff(diff))
+}
+
+func TestPr_FormatPullRequestStatus(t *testing.T) {
+	pr := github.PullRequest{
+		Number: 12,
+		Title:  "Fix the thing",
+		State:  "open",
+		User:   &github.User{Login: "mislav"},
+		Base:   &github.PullRequestSpec{Ref: "main", Repo: &github.Repository{Name: "hub", Owner: &github.User{Login: "github"}}},
+		Head:   &github.PullRequestSpec{Ref: "topic", Repo: &github.Repository{Name: "hub", Owner: &github.User{Login: "github"}}},
+	}
+
+	assert.Equal(t, "#12 success", formatPullRequestStatus(pr, "success", "%i %cS", false))
+	assert.Equal(t, "#12 \033[32msuccess\033[m", formatPullRequestStatus(pr, "success", "%i %cC%cS%Creset", true))
+	assert.Equal(t, "#12 \033[31mfailure\033[m", formatPullRequestStatus(pr, "failure", "%i %cC%cS%Creset", true))
+	assert.Equal(t, "#12 \033[33mpending\033[m", formatPullRequestStatus(pr, "pending", "%i %cC%cS%Creset", true))
+	assert.Equal(t, "#12 \033[m", formatPullRequestStatus(pr, "", "%i %cC%cS%Creset", true))
+}
+
+func TestPr_HasPendingCheck(t *testing.T) {
+	assert.Equal(t, false, hasPendingCheck(nil))
+	assert.Equal(t, false, hasPendingCheck([]github.CIStatus{
+		{State: "success"},
+		{State: "failure"},
+	}))
+	assert.Equal(t, true, hasPendingCheck([]github.CIStatus{
+		{State: "success"},
+		{State: "pending"},
+	}))
+}
+
+func TestPr_StackBranches(t *testing.T) {
+	parents := map[string]string{
+		"feature-a":  "master",
+		"feature-b":  "feature-a",
+		"feature-c":  "feature-b",
+		"feature-b2": "feature-a",
+		"other":      "develop",
+	}
+
+	stack := []string{"feature-a", "feature-b", "feature-b2", "feature-c"}
+	assert.Equal(t, stack, stackBranches("feature-a", parents))
+	assert.Equal(t, stack, stackBranches("feature-c", parents))
+	assert.Equal(t, stack, stackBranches("master", parents))
+	assert.Equal(t, []string{"other"}, stackBranches("other", parents))
+	assert.Equal(t, []string{}, stackBranches("unrelated", parents))
+}
+
+func TestPr_RevertCommitArgs(t *testing.T) {
+	args, err := revertCommitArgs("abc123", true, 3, nil)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"-m", "1", "abc123"}, args)
+
+	args, err = revertCommitArgs("abc123", false, 1, nil)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"abc123"}, args)
+
+	// rebase merge, even with reworded commit messages
+	args, err = revertCommitArgs("abc123", false, 3, []string{"- 1111111", "- 2222222", "- 3333333"})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"abc123~3..abc123"}, args)
+
+	// squash merge
+	args, err = revertCommitArgs("abc123", false, 3, []string{"+ 1111111", "+ 2222222", "+ abc123"})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"abc123"}, args)
+
+	// rebase merge with commits that were dropped or collapsed
+	_, err = revertCommitArgs("abc123", false, 3, []string{"+ 1111111", "- 2222222", "- 3333333"})
+	assert.Equal(t, "2 of the 3 commits leading up to abc123 match the pull request commits", err.Error())
+}
+
+func TestPr_PullRequestMergeBlockers(t *testing.T) {
+	mergeable := true
+	pr := &github.PullRequest{
+		State:          "open",
+		Mergeable:      &mergeable,
+		MergeableState: "clean",
+		Base:           &github.PullRequestSpec{Ref: "main"},
+	}
+	assert.Equal(t, 0, len(pullRequestMergeBlockers(pr, nil, true, nil, nil)))
+
+	protection := &github.BranchProtection{
+		RequiredStatusChecks: &github.RequiredStatusChecks{
+			Strict:   true,
+			Contexts: []string{"build", "lint", "test"},
+		},
+		RequiredPullRequestReviews: &github.RequiredPullRequestReviews{RequiredApprovingReviewCount: 2},
+	}
+	reviews := []github.PullRequestReview{
+		{State: "CHANGES_REQUESTED", User: &github.User{Login: "alice"}},
+		{State: "APPROVED", User: &github.User{Login: "bob"}},
+		{State: "COMMENTED", User: &github.User{Login: "alice"}},
+		{State: "CHANGES_REQUESTED", User: &github.User{Login: "carol"}},
+		{State: "APPROVED", User: &github.User{Login: "carol"}},
+	}
+	statuses := []github.CIStatus{
+		{Context: "build", State: "success"},
+		{Context: "lint", State: "failure"},
+	}
+	pr.MergeableState = "behind"
+	blockers := pullRequestMergeBlockers(pr, protection, true, reviews, statuses)
+	assert.Equal(t, []mergeBlocker{
+		{"changes were requested by alice", mergeBlockedByReviews},
+		{"required check 'lint' has failed", mergeBlockedByChecks},
+		{"required check 'test' is expected", mergeBlockedByChecks},
+		{"the head branch is not up to date with main", mergeBlockedByOutdatedBranch},
+	}, blockers)
+
+	mergeable = false
+	pr.MergeableState = "dirty"
+	pr.Draft = true
+	blockers = pullRequestMergeBlockers(pr, nil, true, nil, nil)
+	assert.Equal(t, []mergeBlocker{
+		{"there are merge conflicts with main", mergeBlockedByConflicts},
+		{"the pull request is a draft", mergeBlockedByOther},
+	}, blockers)
+
+	pr.State = "closed"
+	blockers = pullRequestMergeBlockers(pr, nil, true, nil, nil)
+	assert.Equal(t, []mergeBlocker{{"the pull request is closed", mergeBlockedByOther}}, blockers)
+
+	mergeable = true
+	pr.State = "open"
+	pr.Draft = false
+	pr.MergeableState = "behind"
+	protection = &github.BranchProtection{
+		RequiredStatusChecks: &github.RequiredStatusChecks{
+			Contexts: []string{"build"},
+			Checks:   []github.RequiredStatusCheck{{Context: "build"}, {Context: "deploy"}},
+		},
+	}
+	blockers = pullRequestMergeBlockers(pr, protection, true, nil, []github.CIStatus{{Context: "build", State: "success"}})
+	assert.Equal(t, []mergeBlocker{{"required check 'deploy' is expected", mergeBlockedByChecks}}, blockers)
+
+	protection = &github.BranchProtection{
+		RequiredStatusChecks: &github.RequiredStatusChecks{
+			Checks: []github.RequiredStatusCheck{{Context: "build"}},
+		},
+	}
+	blockers = pullRequestMergeBlockers(pr, protection, true, nil, []github.CIStatus{{Context: "build", State: "success"}})
+	assert.Equal(t, 0, len(blockers))
+
+	pr.MergeableState = "blocked"
+	blockers = pullRequestMergeBlockers(pr, nil, true, nil, nil)
+	assert.Equal(t, []mergeBlocker{{"merging is blocked by the branch protection rules of main", mergeBlockedByOther}}, blockers)
+	assert.Equal(t, 0, len(pullRequestMergeBlockers(pr, nil, false, nil, nil)))
+}
+
+func TestPr_FormatPullRequestWithReviews(t *testing.T) {
+	pr := github.PullRequest{
+		Number: 12,
+		User:   &github.User{Login: "octocat"},
+		Head:   &github.PullRequestSpec{Ref: "feature"},
+		Base:   &github.PullRequestSpec{Ref: "main"},
+	}
+	reviewData := &github.PullRequestReviewData{
+		Reviews: []github.PullRequestReview{
+			{State: "APPROVED", User: &github.User{Login: "rey"}},
+			{State: "CHANGES_REQUESTED", User: &github.User{Login: "finn"}},
+			{State: "CHANGES_REQUESTED", User: &github.User{Login: "poe"}},
+		},
+		Threads: []github.PullRequestReviewThread{
+			{Path: "a.go", IsResolved: true},
+			{Path: "b.go"},
+		},
+	}
+
+	assert.Equal(t, true, usesReviewPlaceholders("%i %rt"))
+	assert.Equal(t, false, usesReviewPlaceholders("%i %rs"))
+	assert.Equal(t, "#12 1 finn, poe 1", formatPullRequestWithReviews(pr, reviewData, "%i %ra %rc %rt", false))
+}
+
+func TestPr_FormatReviewThreads(t *testing.T) {
+	threads := []github.PullRequestReviewThread{
+		{
+			Path:     "main.go",
+			Line:     10,
+			DiffHunk: "@@ -4,6 +4,6 @@ import (\n func main() {\n \tx := 1\n-\ty := 2\n+\ty := 3\n \tfmt.Println(x, y)",
+			Comments: []github.Comment{
+				{Body: "First line\n\nSecond line", User: &github.User{Login: "rey"}},
+			},
+		},
+		{
+			Path:       "README.md",
+			IsResolved: true,
+			Comments: []github.Comment{
+				{Body: "Typo"},
+			},
+		},
+	}
+
+	assert.Equal(t, "main.go:10\n   \tx := 1\n  -\ty := 2\n  +\ty := 3\n   \tfmt.Println(x, y)\n  rey:\n    First line\n\n    Second line\n\nREADME.md (resolved)\n  ghost:\n    Typo\n", formatReviewThreads(threads))
+}
@@ -10,14 +10,17 @@ import (
 
 	"github.com/github/hub/v2/git"
 	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/ui"
 	"github.com/github/hub/v2/utils"
 )
 
 var cmdPullRequest = &Command{
 	Run: pullRequest,
 	Usage: `
-pull-request [-focpd] [-b <BASE>] [-h <HEAD>] [-r <REVIEWERS> ] [-a <ASSIGNEES>] [-M <MILESTONE>] [-l <LABELS>]
+pull-request [-focpd] [-b <BASE>] [-h <HEAD>] [-r <REVIEWERS> ] [-a <ASSIGNEES>] [-M <MILESTONE>] [-l <LABELS>] [--update]
+pull-request --fill[=<STYLE>] [--no-edit]
 pull-request -m <MESSAGE> [--edit]
+pull-request --template <NAME>
 pull-request -F <FILE> [--edit]
 pull-request -i <ISSUE>
 `,
@@ -42,6 +45,26 @@ pull-request -i <ISSUE>
 		Use the message from the first commit on the branch as pull request title
 		and description without opening a text editor.
 
+	--template <NAME>
+		Prefill the text editor with the named template from the
+		"PULL_REQUEST_TEMPLATE/" directory. When that directory has templates and
+		no <NAME> was given, hub offers to choose one on a terminal unless
+		''--no-edit'' is used.
+
+	--fill[=<STYLE>]
+		When the branch has multiple commits, generate the pull request title from
+		the branch name and the description from the commit log. <STYLE> is one of:
+
+		"subjects" (default): a bulleted list of commit subjects
+
+		"full": the full message of each commit
+
+		"conventional": commit subjects grouped by Conventional Commits type
+
+		References such as "Fixes #123" found in commit messages are gathered at
+		the end of the description. Combine with ''--no-edit'' to skip the text
+		editor.
+
 	-F, --file <FILE>
 		Read the pull request title and description from <FILE>. Pass "-" to read
 		from standard input instead. See ''--message'' for the formatting rules.
@@ -97,6 +120,11 @@ pull-request -i <ISSUE>
 	-d, --draft
 		Create the pull request as a draft.
 
+	--update
+		If a pull request for <HEAD> already exists, update its title, description,
+		labels, assignees, reviewers, and milestone instead of failing. When run
+		interactively, hub asks whether to update the existing pull request.
+
 	--no-maintainer-edits
 		When creating a pull request from a fork, this disallows projects
 		maintainers from being able to push to the head branch of this fork.
@@ -121,6 +149,11 @@ pull-request -i <ISSUE>
 	* ''HUB_RETRY_TIMEOUT'':
 		The maximum time to keep retrying after HTTP 422 on ''--push'' (default: 9).
 
+	* ''hub.pullRequestBodyFormat'':
+		The git config value that sets the default ''--fill'' <STYLE>, e.g.
+		''git config hub.pullRequestBodyFormat conventional''. When set, the
+		description is generated in that style with ''--no-edit'' as well.
+
 ## See also:
 
 hub(1), hub-merge(1), hub-checkout(1)
@@ -132,6 +165,10 @@ func init() {
 }
 
 func pullRequest(cmd *Command, args *Args) {
+	if args.Flag.HasReceived("--template") && hasField(args, "--message", "--file") {
+		utils.Check(cmd.UsageError("cannot use --template together with --message or --file"))
+	}
+
 	localRepo, err := github.LocalRepo()
 	utils.Check(err)
 
@@ -256,6 +293,14 @@ of text is the title and the rest is the description.`, fullBase, fullHead))
 		flagPullRequestIssue = parsePullRequestIssueNumber(args.GetParam(0))
 	}
 
+	fillStyle, _ := git.Config("hub.pullRequestBodyFormat")
+	if args.Flag.HasReceived("--fill") {
+		fillStyle = args.Flag.Value("--fill")
+		if fillStyle == "" {
+			fillStyle = "subjects"
+		}
+	}
+
 	if len(flagPullRequestMessage) > 0 {
 		messageBuilder.Message = strings.Join(flagPullRequestMessage, "\n\n")
 		messageBuilder.Edit = flagPullRequestEdit
@@ -263,7 +308,7 @@ of text is the title and the rest is the description.`, fullBase, fullHead))
 		messageBuilder.Message, err = msgFromFile(args.Flag.Value("--file"))
 		utils.Check(err)
 		messageBuilder.Edit = flagPullRequestEdit
-	} else if args.Flag.Bool("--no-edit") {
+	} else if args.Flag.Bool("--no-edit") && fillStyle == "" {
 		commits, _ := git.RefList(baseTracking, head)
 		if len(commits) == 0 {
 			utils.Check(fmt.Errorf("Aborted: no commits detected between %s and %s", baseTracking, head))
@@ -272,7 +317,7 @@ of text is the title and the rest is the description.`, fullBase, fullHead))
 		utils.Check(err)
 		messageBuilder.Message = message
 	} else if flagPullRequestIssue == "" {
-		messageBuilder.Edit = true
+		messageBuilder.Edit = !args.Flag.Bool("--no-edit")
 
 		headForMessage := headTracking
 		if flagPullRequestPush {
@@ -295,11 +340,24 @@ of text is the title and the rest is the description.`, fullBase, fullHead))
 			if commitLogs != "" {
 				messageBuilder.AddCommentedSection("\nChanges:\n\n" + strings.TrimSpace(commitLogs))
 			}
+
+			if fillStyle != "" {
+				messages := []string{}
+				for i := len(commits) - 1; i >= 0; i-- {
+					commitMessage, err := git.Show(commits[i])
+					utils.Check(err)
+					messages = append(messages, commitMessage)
+				}
+				body, err := formatPullRequestBody(fillStyle, messages)
+				utils.Check(err)
+				message = humanizeBranchName(head) + "\n\n" + body
+			}
 		}
 
 		workdir, _ := git.WorkdirName()
 		if workdir != "" {
-			template, _ := github.ReadTemplate(github.PullRequestTemplate, workdir)
+			template, err := readTemplate(github.PullRequestTemplate, workdir, args)
+			utils.Check(err)
 			if template != "" {
 				message = message + "\n\n\n" + template
 			}
@@ -324,7 +382,7 @@ of text is the title and the rest is the description.`, fullBase, fullHead))
 		}
 	}
 
-	milestoneNumber, err := milestoneValueToNumber(args.Flag.Value("--milestone"), client, baseProject)
+	milestoneNumber, err := milestoneValueToNumber(args.Flag.Value("--milestone"), client, baseProject, "open")
 	utils.Check(err)
 
 	var pullRequestURL string
@@ -386,6 +444,16 @@ of text is the title and the rest is the description.`, fullBase, fullHead))
 			}
 		}
 
+		if err != nil && title != "" && strings.Contains(err.Error(), "A pull request already exists") {
+			update := args.Flag.Bool("--update")
+			if !update && ui.IsTerminal(os.Stdin) && ui.IsTerminal(os.Stdout) {
+				update = promptConfirm(fmt.Sprintf("A pull request for %s already exists. Update it?", fullHead))
+			}
+			if update {
+				pr, err = updateExistingPullRequest(client, baseProject, fullHead, title, body)
+			}
+		}
+
 		if err == nil {
 			defer messageBuilder.Cleanup()
 		}
@@ -413,31 +481,163 @@ of text is the title and the rest is the description.`, fullBase, fullHead))
 		}
 
 		flagPullRequestReviewers := commaSeparated(args.Flag.AllValues("--reviewer"))
-		if len(flagPullRequestReviewers) > 0 {
-			userReviewers := []string{}
-			teamReviewers := []string{}
-			for _, reviewer := range flagPullRequestReviewers {
-				if strings.Contains(reviewer, "/") {
-					teamName := strings.SplitN(reviewer, "/", 2)[1]
-					if !pr.HasRequestedTeam(teamName) {
-						teamReviewers = append(teamReviewers, teamName)
+		err = requestReviewers(client, baseProject, pr, flagPullRequestReviewers)
+		utils.Check(err)
+	}
+
+	args.NoForward()
+	printBrowseOrCopy(args, pullRequestURL, args.Flag.Bool("--browse"), args.Flag.Bool("--copy"))
+}
+
+// formatPullRequestBody generates a pull request description out of commit
+// messages, given in chronological order
+func formatPullRequestBody(style string, messages []string) (string, error) {
+	trailerRe := regexp.MustCompile(`(?im)^\s*((?:close[sd]?|fix(?:e[sd])?|resolve[sd]?):?\s+[\w./-]*#\d+)\s*$`)
+	coauthorRe := regexp.MustCompile(`(?im)^(Co-authored-by|Signed-off-by):.*$`)
+	conventionalRe := regexp.MustCompile(`^(\w+)(?:\(([^)]+)\))?(!)?:\s*(.+)$`)
+
+	references := []string{}
+	seen := map[string]bool{}
+	subjects := []string{}
+	bodies := []string{}
+	for _, message := range messages {
+		for _, m := range trailerRe.FindAllStringSubmatch(message, -1) {
+			if !seen[strings.ToLower(m[1])] {
+				seen[strings.ToLower(m[1])] = true
+				references = append(references, m[1])
+			}
+		}
+		message = coauthorRe.ReplaceAllString(trailerRe.ReplaceAllString(message, ""), "")
+		subject, body := github.SplitTitleBody(message)
+		subjects = append(subjects, subject)
+		bodies = append(bodies, body)
+	}
+
+	sections := []string{}
+	switch style {
+	case "subjects":
+		lines := []string{}
+		for _, subject := range subjects {
+			lines = append(lines, "- "+subject)
+		}
+		sections = append(sections, strings.Join(lines, "\n"))
+	case "full":
+		for i, subject := range subjects {
+			section := "### " + subject
+			if bodies[i] != "" {
+				section += "\n\n" + bodies[i]
+			}
+			sections = append(sections, section)
+		}
+	case "conventional":
+		groups := []struct {
+			heading string
+			types   []string
+		}{
+			{"Features", []string{"feat"}},
+			{"Bug Fixes", []string{"fix"}},
+			{"Performance Improvements", []string{"perf"}},
+			{"Refactoring", []string{"refactor"}},
+			{"Documentation", []string{"docs"}},
+			{"Tests", []string{"test"}},
+			{"Build System", []string{"build", "ci"}},
+			{"Chores", []string{"chore", "style"}},
+		}
+		items := map[string][]string{}
+		other := []string{}
+	subjectLoop:
+		for _, subject := range subjects {
+			m := conventionalRe.FindStringSubmatch(subject)
+			if m != nil {
+				item := "- " + m[4]
+				if m[2] != "" {
+					item = fmt.Sprintf("- **%s:** %s", m[2], m[4])
+				}
+				if m[3] != "" {
+					item += " (BREAKING)"
+				}
+				for _, group := range groups {
+					for _, t := range group.types {
+						if strings.EqualFold(m[1], t) {
+							items[group.heading] = append(items[group.heading], item)
+							continue subjectLoop
+						}
 					}
-				} else if !pr.HasRequestedReviewer(reviewer) {
-					userReviewers = append(userReviewers, reviewer)
 				}
 			}
-			if len(userReviewers) > 0 || len(teamReviewers) > 0 {
-				err = client.RequestReview(baseProject, pr.Number, map[string]interface{}{
-					"reviewers":      userReviewers,
-					"team_reviewers": teamReviewers,
-				})
-				utils.Check(err)
+			other = append(other, "- "+subject)
+		}
+		for _, group := range groups {
+			if len(items[group.heading]) > 0 {
+				sections = append(sections, fmt.Sprintf("### %s\n\n%s", group.heading, strings.Join(items[group.heading], "\n")))
 			}
 		}
+		if len(other) > 0 {
+			sections = append(sections, fmt.Sprintf("### Other Changes\n\n%s", strings.Join(other, "\n")))
+		}
+	default:
+		return "", fmt.Errorf("invalid pull request body format: '%s'", style)
 	}
 
-	args.NoForward()
-	printBrowseOrCopy(args, pullRequestURL, args.Flag.Bool("--browse"), args.Flag.Bool("--copy"))
+	if len(references) > 0 {
+		sections = append(sections, strings.Join(references, "\n"))
+	}
+	return strings.Join(sections, "\n\n"), nil
+}
+
+// humanizeBranchName turns "fix-login_form" into "Fix login form"
+func humanizeBranchName(branch string) string {
+	if i := strings.LastIndex(branch, "/"); i >= 0 {
+		branch = branch[i+1:]
+	}
+	name := strings.NewReplacer("-", " ", "_", " ").Replace(branch)
+	if name == "" {
+		return name
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+func updateExistingPullRequest(client *github.Client, project *github.Project, head, title, body string) (*github.PullRequest, error) {
+	filters := map[string]interface{}{
+		"head":  head,
+		"state": "open",
+	}
+	pulls, err := client.FetchPullRequests(project, filters, 1, nil)
+	if err != nil {
+		return nil, err
+	} else if len(pulls) == 0 {
+		return nil, fmt.Errorf("Error: could not find an open pull request for %s", head)
+	}
+
+	params := map[string]interface{}{
+		"title": title,
+		"body":  body,
+	}
+	return client.UpdatePullRequest(project, pulls[0].Number, params)
+}
+
+func requestReviewers(client *github.Client, project *github.Project, pr *github.PullRequest, reviewers []string) error {
+	userReviewers := []string{}
+	teamReviewers := []string{}
+	for _, reviewer := range reviewers {
+		if strings.Contains(reviewer, "/") {
+			teamName := strings.SplitN(reviewer, "/", 2)[1]
+			if !pr.HasRequestedTeam(teamName) {
+				teamReviewers = append(teamReviewers, teamName)
+			}
+		} else if !pr.HasRequestedReviewer(reviewer) {
+			userReviewers = append(userReviewers, reviewer)
+		}
+	}
+
+	if len(userReviewers) == 0 && len(teamReviewers) == 0 {
+		return nil
+	}
+
+	return client.RequestReview(project, pr.Number, map[string]interface{}{
+		"reviewers":      userReviewers,
+		"team_reviewers": teamReviewers,
+	})
 }
 
 func parsePullRequestProject(context *github.Project, s string) (p *github.Project, ref string) {
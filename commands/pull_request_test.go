@@ -31,3 +31,31 @@ func TestPullRequest_ParsePullRequestProject(t *testing.T) {
 	assert.Equal(t, "mojombo", p.Owner)
 	assert.Equal(t, "jekyll", p.Name)
 }
+
+func TestPullRequest_FormatPullRequestBody(t *testing.T) {
+	messages := []string{
+		"feat(api)!: drop v1 endpoints\n\nLong explanation.\n\nCloses #4\nSigned-off-by: NAME <email@example.com>",
+		"fix: handle nil pointer\n\nFixes #7",
+		"Tweak wording\n\nfixes #7",
+	}
+
+	body, err := formatPullRequestBody("subjects", messages)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "- feat(api)!: drop v1 endpoints\n- fix: handle nil pointer\n- Tweak wording\n\nCloses #4\nFixes #7", body)
+
+	body, err = formatPullRequestBody("full", messages)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "### feat(api)!: drop v1 endpoints\n\nLong explanation.\n\n### fix: handle nil pointer\n\n### Tweak wording\n\nCloses #4\nFixes #7", body)
+
+	body, err = formatPullRequestBody("conventional", messages)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "### Features\n\n- **api:** drop v1 endpoints (BREAKING)\n\n### Bug Fixes\n\n- handle nil pointer\n\n### Other Changes\n\n- Tweak wording\n\nCloses #4\nFixes #7", body)
+
+	_, err = formatPullRequestBody("fancy", messages)
+	assert.Equal(t, "invalid pull request body format: 'fancy'", err.Error())
+}
+
+func TestPullRequest_HumanizeBranchName(t *testing.T) {
+	assert.Equal(t, "Login form", humanizeBranchName("feature/login-form"))
+	assert.Equal(t, "Fix the thing", humanizeBranchName("fix-the_thing"))
+}
@@ -5,13 +5,17 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/atotto/clipboard"
 	"github.com/github/hub/v2/git"
+	"github.com/github/hub/v2/github"
 	"github.com/github/hub/v2/ui"
 	"github.com/github/hub/v2/utils"
+	"github.com/kballard/go-shellquote"
 )
 
 type stringSliceValue []string
@@ -120,3 +124,108 @@ func printBrowseOrCopy(args *Args, msg string, openBrowser bool, performCopy boo
 		})
 	}
 }
+
+// printWithPager sends output through the pager that git would use when
+// stdout is a terminal, and prints it directly otherwise
+func printWithPager(output string) error {
+	pager := ""
+	if ui.IsTerminal(os.Stdout) {
+		pager, _ = git.Pager()
+	}
+	if pager == "" || pager == "cat" {
+		ui.Print(output)
+		return nil
+	}
+
+	pagerArgs, err := shellquote.Split(pager)
+	if err != nil {
+		return err
+	}
+	if len(pagerArgs) == 0 {
+		ui.Print(output)
+		return nil
+	}
+	// like git, let the shell handle pagers such as "LESS=-R less" or "less | tee"
+	if strings.ContainsAny(pager, "|&;<>()$`\\\"' \t\n*?[#~=%") {
+		pagerArgs = []string{"sh", "-c", pager}
+	}
+
+	env := os.Environ()
+	if os.Getenv("LESS") == "" {
+		env = append(env, "LESS=FRX")
+	}
+	if os.Getenv("LV") == "" {
+		env = append(env, "LV=-c")
+	}
+
+	c := exec.Command(pagerArgs[0], pagerArgs[1:]...)
+	c.Stdin = strings.NewReader(output)
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+	c.Env = env
+	return c.Run()
+}
+
+// promptConfirm asks a yes/no question and reports whether it was answered
+// affirmatively. Anything other than "y" or "yes" counts as a "no".
+func promptConfirm(question string) bool {
+	ui.Printf("%s [y/N] ", question)
+	scanner := bufio.NewScanner(os.Stdin)
+	if !scanner.Scan() {
+		return false
+	}
+	answer := strings.TrimSpace(scanner.Text())
+	return strings.EqualFold(answer, "y") || strings.EqualFold(answer, "yes")
+}
+
+// promptChoice asks to pick one of the options by number and returns its
+// index, or -1 if nothing valid was chosen
+func promptChoice(question string, options []string) int {
+	for i, option := range options {
+		ui.Printf("%d) %s\n", i+1, option)
+	}
+	ui.Printf("%s [1-%d]: ", question, len(options))
+
+	scanner := bufio.NewScanner(os.Stdin)
+	if !scanner.Scan() {
+		return -1
+	}
+	choice, err := strconv.Atoi(strings.TrimSpace(scanner.Text()))
+	if err != nil || choice < 1 || choice > len(options) {
+		return -1
+	}
+	return choice - 1
+}
+
+// readTemplate returns the template named by `--template`. Otherwise, when
+// multiple named templates exist, the user is asked to pick one on a terminal
+// before falling back to the default template of that kind. There is no prompt
+// with `--no-edit`, since nobody is going to look at the message.
+func readTemplate(kind, workdir string, args *Args) (string, error) {
+	templates, err := github.ListTemplates(kind, workdir)
+	if err != nil {
+		return "", err
+	}
+
+	if args.Flag.HasReceived("--template") {
+		name := args.Flag.Value("--template")
+		for _, template := range templates {
+			if strings.EqualFold(template.Name, name) {
+				return template.Read()
+			}
+		}
+		return "", fmt.Errorf("Error: could not find template '%s'", name)
+	}
+
+	if len(templates) > 0 && !args.Flag.Bool("--no-edit") && ui.IsTerminal(os.Stdin) && ui.IsTerminal(os.Stdout) {
+		names := []string{}
+		for _, template := range templates {
+			names = append(names, template.Name)
+		}
+		if i := promptChoice("Choose a template, or press Enter for the default", names); i >= 0 {
+			return templates[i].Read()
+		}
+	}
+
+	return github.ReadTemplate(kind, workdir)
+}
@@ -0,0 +1,72 @@
+Feature: hub issue close and reopen
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "cornwe19" on github.com with OAuth token "OTOKEN"
+
+  Scenario: Close an issue
+    Given the GitHub API server:
+      """
+      patch('/repos/github/hub/issues/1337') {
+        assert :state => "closed", :state_reason => "completed"
+        json :number => 1337
+      }
+      """
+    When I successfully run `hub issue close 1337`
+    Then the output should contain exactly ""
+
+  Scenario: Close an issue as not planned with a comment
+    Given the GitHub API server:
+      """
+      post('/repos/github/hub/issues/1337/comments') {
+        assert :body => "Out of scope for this project."
+        status 201
+        json :id => 101
+      }
+      patch('/repos/github/hub/issues/1337') {
+        assert :state => "closed", :state_reason => "not_planned"
+        json :number => 1337
+      }
+      """
+    When I successfully run `hub issue close 1337 --reason not-planned -m "Out of scope for this project."`
+    Then the output should contain exactly ""
+
+  Scenario: Close an issue as a duplicate
+    Given the GitHub API server:
+      """
+      post('/repos/github/hub/issues/1337/comments') {
+        assert :body => "Duplicate of #42\n\nSee the discussion over there."
+        status 201
+        json :id => 101
+      }
+      patch('/repos/github/hub/issues/1337') {
+        assert :state => "closed", :state_reason => "not_planned"
+        json :number => 1337
+      }
+      """
+    When I successfully run `hub issue close 1337 --duplicate-of 42 -m "See the discussion over there."`
+    Then the output should contain exactly ""
+
+  Scenario: Invalid reason
+    When I run `hub issue close 1337 --reason wontfix`
+    Then the exit status should be 1
+    And the stderr should contain "invalid reason: 'wontfix'"
+
+  Scenario: Reopen an issue with a comment from a file
+    Given a file named "reason.txt" with:
+      """
+      The fix was reverted.
+      """
+    Given the GitHub API server:
+      """
+      post('/repos/github/hub/issues/1337/comments') {
+        assert :body => "The fix was reverted."
+        status 201
+        json :id => 101
+      }
+      patch('/repos/github/hub/issues/1337') {
+        assert :state => "open", :state_reason => "reopened"
+        json :number => 1337
+      }
+      """
+    When I successfully run `hub issue reopen 1337 -F reason.txt`
+    Then the output should contain exactly ""
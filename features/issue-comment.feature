@@ -0,0 +1,115 @@
+Feature: hub issue comment
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "cornwe19" on github.com with OAuth token "OTOKEN"
+
+  Scenario: Comment on an issue
+    Given the GitHub API server:
+      """
+      post('/repos/github/hub/issues/1337/comments') {
+        assert :body => "Confirmed on macOS\n\nSteps are in the description."
+        status 201
+        json :id => 101, :html_url => "https://github.com/github/hub/issues/1337#issuecomment-101"
+      }
+      """
+    When I successfully run `hub issue comment 1337 -m "Confirmed on macOS" -m "Steps are in the description."`
+    Then the output should contain exactly:
+      """
+      https://github.com/github/hub/issues/1337#issuecomment-101\n
+      """
+
+  Scenario: Comment from the text editor
+    Given the git commit editor is "vim"
+    And the text editor adds:
+      """
+      Me too
+      """
+    Given the GitHub API server:
+      """
+      post('/repos/github/hub/issues/1337/comments') {
+        assert :body => "Me too"
+        status 201
+        json :id => 101, :html_url => "https://github.com/github/hub/issues/1337#issuecomment-101"
+      }
+      """
+    When I successfully run `hub issue comment 1337`
+    Then the output should contain exactly:
+      """
+      https://github.com/github/hub/issues/1337#issuecomment-101\n
+      """
+    And the file ".git/ISSUE_COMMENT_EDITMSG" should not exist
+
+  Scenario: Empty comment from the text editor
+    Given the git commit editor is "true"
+    When I run `hub issue comment 1337`
+    Then the stderr should contain exactly "Aborting due to empty comment\n"
+    And the exit status should be 1
+
+  Scenario: Edit the last comment in the text editor
+    Given the git commit editor is "vim"
+    And the text editor adds:
+      """
+      Update: fixed in master.
+      """
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/issues/1337/comments') {
+        assert :per_page => "100"
+        json [
+          { :id => 99, :body => "First!", :user => { :login => "cornwe19" } },
+          { :id => 100, :body => "Works for me", :user => { :login => "cornwe19" } },
+          { :id => 101, :body => "Not for me", :user => { :login => "octocat" } },
+        ]
+      }
+      patch('/repos/github/hub/issues/comments/100') {
+        assert :body => "Update: fixed in master.\n\nWorks for me"
+        json :id => 100, :html_url => "https://github.com/github/hub/issues/1337#issuecomment-100"
+      }
+      """
+    When I successfully run `hub issue comment 1337 --edit-last`
+    Then the output should contain exactly:
+      """
+      https://github.com/github/hub/issues/1337#issuecomment-100\n
+      """
+
+  Scenario: Replace the last comment
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/issues/1337/comments') {
+        json [
+          { :id => 100, :body => "Works for me", :user => { :login => "cornwe19" } },
+        ]
+      }
+      patch('/repos/github/hub/issues/comments/100') {
+        assert :body => "Never mind"
+        json :id => 100, :html_url => "https://github.com/github/hub/issues/1337#issuecomment-100"
+      }
+      """
+    When I successfully run `hub issue comment 1337 --edit-last -m "Never mind"`
+    Then the output should contain exactly:
+      """
+      https://github.com/github/hub/issues/1337#issuecomment-100\n
+      """
+
+  Scenario: No previous comment to edit
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/issues/1337/comments') {
+        json [
+          { :id => 101, :body => "Not for me", :user => { :login => "octocat" } },
+        ]
+      }
+      """
+    When I run `hub issue comment 1337 --edit-last -m "Never mind"`
+    Then the stderr should contain exactly "Error: no comments by cornwe19 found on #1337\n"
+    And the exit status should be 1
+
+  Scenario: Delete a comment
+    Given the GitHub API server:
+      """
+      delete('/repos/github/hub/issues/comments/100') {
+        status 204
+      }
+      """
+    When I successfully run `hub issue comment 1337 --delete 100`
+    Then the output should contain exactly ""
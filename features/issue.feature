@@ -70,6 +70,107 @@ Feature: hub issue
            #13  Second issue\n
       """
 
+  Scenario: Search issues
+    Given the GitHub API server:
+    """
+    get('/search/issues') {
+      assert :q => "repo:github/hub is:issue is:open assignee:Cornwe19 label:bug no:assignee -label:wontfix",
+             :sort => "updated",
+             :order => "asc"
+      page = (params[:page] || 1).to_i
+      if page < 2
+        response.headers['Link'] = %(</search/issues?#{request.query_string}&page=#{page+1}>; rel="next")
+        json :total_count => 2, :incomplete_results => false, :items => [
+          { :number => 102,
+            :title => "First issue",
+            :state => "open",
+            :user => { :login => "octocat" },
+          },
+        ]
+      else
+        json :total_count => 2, :incomplete_results => false, :items => [
+          { :number => 13,
+            :title => "Second issue",
+            :state => "open",
+            :user => { :login => "octocat" },
+          },
+        ]
+      end
+    }
+    """
+    When I successfully run `hub issue -a Cornwe19 -l bug -o updated -^ --search "no:assignee -label:wontfix"`
+    Then the output should contain exactly:
+      """
+          #102  First issue
+           #13  Second issue\n
+      """
+
+  Scenario: Search issues by milestone number
+    Given the GitHub API server:
+    """
+    get('/repos/github/hub/milestones') {
+      assert :state => "all"
+      json [
+        { :number => 3, :title => "Hello World!" },
+        { :number => 4, :title => "Future" },
+      ]
+    }
+    get('/search/issues') {
+      assert :q => 'repo:github/hub is:issue is:open milestone:"Hello World!" in:title crash',
+             :sort => "created"
+      json :total_count => 1, :incomplete_results => false, :items => [
+        { :number => 102,
+          :title => "First issue",
+          :state => "open",
+          :user => { :login => "octocat" },
+        },
+      ]
+    }
+    """
+    When I successfully run `hub issue -M 3 --search "in:title crash"`
+    Then the output should contain exactly:
+      """
+          #102  First issue\n
+      """
+
+  Scenario: Search issues by milestone title
+    Given the GitHub API server:
+    """
+    get('/repos/github/hub/milestones') {
+      halt 400
+    }
+    get('/search/issues') {
+      assert :q => "repo:github/hub is:issue is:open milestone:v2.0 crash"
+      json :total_count => 0, :incomplete_results => false, :items => []
+    }
+    """
+    When I successfully run `hub issue -M v2.0 --search crash`
+    Then the output should contain exactly ""
+
+  Scenario: Search closed issues including pull requests
+    Given the GitHub API server:
+    """
+    get('/search/issues') {
+      assert :q => "repo:github/hub is:closed reason:not_planned",
+             :sort => "created",
+             :order => nil,
+             :per_page => "1"
+      json :total_count => 10, :incomplete_results => false, :items => [
+        { :number => 999,
+          :title => "First pull",
+          :state => "closed",
+          :user => { :login => "octocat" },
+          :pull_request => { },
+        },
+      ]
+    }
+    """
+    When I successfully run `hub issue -s closed --include-pulls -L 1 --search reason:not_planned -f "%I %S%n"`
+    Then the output should contain exactly:
+      """
+      999 closed\n
+      """
+
   Scenario: Fetch issues and pull requests
     Given the GitHub API server:
     """
@@ -119,6 +220,9 @@ Feature: hub issue
   Scenario: Fetch issues assigned to milestone by number
     Given the GitHub API server:
       """
+      get('/repos/github/hub/milestones') {
+        json [{ :number => 12, :title => "v1.0" }]
+      }
       get('/repos/github/hub/issues') {
         assert :milestone => "12"
         json []
@@ -126,6 +230,22 @@ Feature: hub issue
       """
     When I successfully run `hub issue -M 12`
 
+  Scenario: Fetch issues assigned to milestone with a numeric title
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/milestones') {
+        json [
+          { :number => 7, :title => "2024" },
+          { :number => 2024, :title => "Someday" },
+        ]
+      }
+      get('/repos/github/hub/issues') {
+        assert :milestone => "7"
+        json []
+      }
+      """
+    When I successfully run `hub issue -M 2024`
+
   Scenario: Fetch issues assigned to milestone by name
     Given the GitHub API server:
       """
@@ -392,6 +512,9 @@ Feature: hub issue
   Scenario: Create an issue with milestone and assignees
     Given the GitHub API server:
       """
+      get('/repos/github/hub/milestones') {
+        json [{ :number => 12, :title => "v1.0" }]
+      }
       post('/repos/github/hub/issues') {
         assert :title => "hello",
                :body => "",
@@ -540,6 +663,88 @@ Feature: hub issue
       https://github.com/github/hub/issues/1337\n
       """
 
+  Scenario: Named issue template
+    Given the git commit editor is "vim"
+    And the text editor adds:
+      """
+      hello
+      """
+    And a file named ".github/ISSUE_TEMPLATE/bug_report.md" with:
+      """
+      I want to report a bug
+      """
+    And a file named ".github/ISSUE_TEMPLATE/feature_request.md" with:
+      """
+      There is a feature that I need!
+      """
+    Given the GitHub API server:
+      """
+      post('/repos/github/hub/issues') {
+        assert :title => "hello",
+               :body => "There is a feature that I need!"
+
+        status 201
+        json :html_url => "https://github.com/github/hub/issues/1337"
+      }
+      """
+    When I successfully run `hub issue create --template feature_request`
+    Then the output should contain exactly:
+      """
+      https://github.com/github/hub/issues/1337\n
+      """
+
+  Scenario: Unknown issue template
+    Given a file named ".github/ISSUE_TEMPLATE/bug_report.md" with:
+      """
+      I want to report a bug
+      """
+    When I run `hub issue create --template question`
+    Then the stderr should contain exactly "Error: could not find template 'question'\n"
+    And the exit status should be 1
+
+  Scenario: Issue template together with a message
+    When I run `hub issue create --template bug_report -m "hello"`
+    Then the stderr should contain "cannot use --template together with --message or --file"
+    And the exit status should be 1
+
+  Scenario: Issue form template
+    Given the git commit editor is "vim"
+    And the text editor adds:
+      """
+      hello
+      """
+    And a file named ".github/ISSUE_TEMPLATE/bug.yml" with:
+      """
+      name: Bug report
+      description: File a bug report
+      body:
+        - type: markdown
+          attributes:
+            value: Thanks for taking the time!
+        - type: input
+          attributes:
+            label: Version
+            placeholder: e.g. 2.14.2
+        - type: textarea
+          attributes:
+            label: What happened?
+      """
+    Given the GitHub API server:
+      """
+      post('/repos/github/hub/issues') {
+        assert :title => "hello",
+               :body => "### Version\n\n<!-- e.g. 2.14.2 -->\n\n### What happened?"
+
+        status 201
+        json :html_url => "https://github.com/github/hub/issues/1337"
+      }
+      """
+    When I successfully run `hub issue create --template bug`
+    Then the output should contain exactly:
+      """
+      https://github.com/github/hub/issues/1337\n
+      """
+
   Scenario: Multiple issue templates with default
     Given the git commit editor is "vim"
     And the text editor adds:
@@ -634,6 +839,9 @@ Feature: hub issue
   Scenario: Update an issue's milestone
     Given the GitHub API server:
       """
+      get('/repos/github/hub/milestones') {
+        json [{ :number => 42, :title => "v4.2" }]
+      }
       patch('/repos/github/hub/issues/1337') {
         assert :title => :no,
                :body => :no,
@@ -654,6 +862,9 @@ Feature: hub issue
           { :number => 42, :title => "Hello World!" }
         ]
       }
+      get('/repos/github/hub/milestones') {
+        json [{ :number => 42, :title => "v4.2" }]
+      }
       patch('/repos/github/hub/issues/1337') {
         assert :title => :no,
                :body => :no,
@@ -680,6 +891,9 @@ Feature: hub issue
   Scenario: Update an issue's title, labels, milestone, and assignees
     Given the GitHub API server:
       """
+      get('/repos/github/hub/milestones') {
+        json [{ :number => 42, :title => "v4.2" }]
+      }
       patch('/repos/github/hub/issues/1337') {
         assert :title => "Not workie, pls fix",
                :body => "",
@@ -752,6 +966,81 @@ Feature: hub issue
     Then the stderr should contain "please specify fields to update"
     Then the stderr should contain "Usage: hub issue"
 
+  Scenario: Update multiple issues
+    Given the GitHub API server:
+      """
+      patch('/repos/github/hub/issues/:number') {
+        assert :title => :no,
+               :body => :no,
+               :labels => ["bug"],
+               :state => "closed"
+        json :number => params[:number].to_i
+      }
+      """
+    When I successfully run `hub issue update 12 13 14 -l bug -s closed`
+    Then the output should contain exactly:
+      """
+      #12 updated
+      #13 updated
+      #14 updated\n
+      """
+
+  Scenario: Update issues matching a search query
+    Given the GitHub API server:
+      """
+      get('/search/issues') {
+        assert :q => "repo:github/hub is:open label:needs-triage"
+        json :total_count => 2, :incomplete_results => false, :items => [
+          { :number => 102, :title => "First issue", :state => "open" },
+          { :number => 13, :title => "Second issue", :state => "open" },
+        ]
+      }
+      patch('/repos/github/hub/issues/:number') {
+        assert :labels => ["triaged"]
+        json :number => params[:number].to_i
+      }
+      """
+    When I successfully run `hub issue update --query "is:open label:needs-triage" -l triaged`
+    Then the output should contain exactly:
+      """
+      #102 updated
+      #13 updated\n
+      """
+
+  Scenario: Report issues that failed to update
+    Given the GitHub API server:
+      """
+      patch('/repos/github/hub/issues/:number') {
+        if params[:number] == "13"
+          status 404
+          json :message => "Not Found"
+        else
+          json :number => params[:number].to_i
+        end
+      }
+      """
+    When I run `hub issue update 12 13 -s closed`
+    Then the exit status should be 1
+    And the stdout should contain exactly "#12 updated\n"
+    And the stderr should contain exactly:
+      """
+      #13 failed: Error updating issue: Not Found (HTTP 404)
+      Error: failed to update 1 of 2 issues\n
+      """
+
+  Scenario: Preview updating multiple issues
+    When I successfully run `hub --noop issue update 12 13 -a Cornwe19`
+    Then the output should contain exactly:
+      """
+      Would update issue #12 for github/hub
+      Would update issue #13 for github/hub\n
+      """
+
+  Scenario: Update the title of multiple issues
+    When I run `hub issue update 12 13 -m "New title"`
+    Then the exit status should be 1
+    And the stderr should contain "the title and description can only be changed for one issue at a time"
+
   Scenario: Fetch issue labels
     Given the GitHub API server:
     """
@@ -0,0 +1,209 @@
+Feature: hub label
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "cornwe19" on github.com with OAuth token "OTOKEN"
+
+  Scenario: List labels
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/labels') {
+        json [
+          { :name => "bug", :color => "d73a4a" },
+          { :name => "enhancement", :color => "a2eeef" },
+        ]
+      }
+      """
+    When I successfully run `hub label`
+    Then the output should contain exactly:
+      """
+      bug
+      enhancement\n
+      """
+
+  Scenario: Create a label
+    Given the GitHub API server:
+      """
+      post('/repos/github/hub/labels') {
+        assert :name => "needs triage",
+               :color => "d73a4a",
+               :description => "Not looked at yet"
+        status 201
+        json :name => "needs triage", :color => "d73a4a"
+      }
+      """
+    When I successfully run `hub label create "needs triage" -c "#D73A4A" -d "Not looked at yet"`
+    Then the output should contain exactly:
+      """
+      Created label 'needs triage'\n
+      """
+
+  Scenario: Create a label with an invalid color
+    When I run `hub label create bug -c red`
+    Then the exit status should be 1
+    And the stderr should contain exactly "invalid label color: 'red'\n"
+
+  Scenario: Rename a label
+    Given the GitHub API server:
+      """
+      patch('/repos/github/hub/labels/:name') {
+        halt 404 unless params[:name] == "needs triage"
+        assert :new_name => "triage",
+               :color => :no,
+               :description => :no
+        json :name => "triage", :color => "d73a4a"
+      }
+      """
+    When I successfully run `hub label edit "needs triage" -n triage`
+    Then the output should contain exactly:
+      """
+      Updated label 'triage'\n
+      """
+
+  Scenario: Edit a label without specifying fields to update
+    When I run `hub label edit bug`
+    Then the exit status should be 1
+    And the stderr should contain "please specify fields to update"
+
+  Scenario: Delete a label
+    Given the GitHub API server:
+      """
+      delete('/repos/github/hub/labels/:name') {
+        halt 404 unless params[:name] == "wontfix"
+        status 204
+      }
+      """
+    When I successfully run `hub label delete wontfix`
+    Then the output should contain exactly:
+      """
+      Deleted label 'wontfix'\n
+      """
+
+  Scenario: Sync labels from a file
+    Given a file named "labels.yml" with:
+      """
+      - name: bug
+        color: d73a4a
+        description: Something isn't working
+      - name: enhancement
+        color: a2eeef
+      - name: needs-triage
+        color: ededed
+      """
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/labels') {
+        json [
+          { :name => "Bug", :color => "d73a4a", :description => "Something isn't working" },
+          { :name => "enhancement", :color => "a2eeef", :description => nil },
+          { :name => "wontfix", :color => "ffffff", :description => nil },
+        ]
+      }
+      patch('/repos/github/hub/labels/:name') {
+        halt 404 unless params[:name] == "Bug"
+        assert :new_name => "bug",
+               :color => "d73a4a",
+               :description => "Something isn't working"
+        json :name => "bug"
+      }
+      post('/repos/github/hub/labels') {
+        assert :name => "needs-triage",
+               :color => "ededed",
+               :description => ""
+        status 201
+        json :name => "needs-triage"
+      }
+      """
+    When I successfully run `hub label sync --file labels.yml`
+    Then the output should contain exactly:
+      """
+      Updated label 'bug'
+      Created label 'needs-triage'\n
+      """
+
+  Scenario: Preview syncing labels with pruning
+    Given a file named "labels.yml" with:
+      """
+      - name: bug
+        color: d73a4a
+      """
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/labels') {
+        json [
+          { :name => "bug", :color => "d73a4a", :description => nil },
+          { :name => "wontfix", :color => "ffffff", :description => nil },
+        ]
+      }
+      """
+    When I successfully run `hub --noop label sync -F labels.yml --prune`
+    Then the output should contain exactly:
+      """
+      Would delete label 'wontfix'\n
+      """
+
+  Scenario: Sync labels from an invalid file
+    Given a file named "labels.yml" with:
+      """
+      - name: bug
+        color: d73a4a
+      - name: BUG
+        color: d73a4a
+      """
+    When I run `hub label sync -F labels.yml`
+    Then the exit status should be 1
+    And the stderr should contain exactly "Error reading labels from labels.yml: label 'BUG' is declared more than once\n"
+
+  Scenario: Labels already in sync
+    Given a file named "labels.yml" with:
+      """
+      - name: bug
+        color: d73a4a
+      """
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/labels') {
+        json [
+          { :name => "bug", :color => "d73a4a", :description => nil },
+        ]
+      }
+      """
+    When I successfully run `hub label sync -F labels.yml`
+    Then the output should contain exactly:
+      """
+      Labels for github/hub are up to date\n
+      """
+
+  Scenario: Clone labels from another repository
+    Given the GitHub API server:
+      """
+      get('/repos/mislav/dotfiles/labels') {
+        json [
+          { :name => "bug", :color => "d73a4a", :description => "Something isn't working" },
+          { :name => "help wanted", :color => "008672", :description => "" },
+        ]
+      }
+      get('/repos/github/hub/labels') {
+        json [
+          { :name => "bug", :color => "ee0701", :description => nil },
+        ]
+      }
+      patch('/repos/github/hub/labels/:name') {
+        halt 404 unless params[:name] == "bug"
+        assert :new_name => "bug",
+               :color => "d73a4a",
+               :description => "Something isn't working"
+        json :name => "bug"
+      }
+      post('/repos/github/hub/labels') {
+        assert :name => "help wanted",
+               :color => "008672"
+        status 201
+        json :name => "help wanted"
+      }
+      """
+    When I successfully run `hub label clone mislav/dotfiles`
+    Then the output should contain exactly:
+      """
+      Updated label 'bug'
+      Created label 'help wanted'\n
+      """
@@ -0,0 +1,182 @@
+Feature: hub milestone
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "cornwe19" on github.com with OAuth token "OTOKEN"
+
+  Scenario: List milestones
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/milestones') {
+        assert :state => nil,
+               :sort => nil,
+               :direction => "desc"
+        json [
+          { :number => 12,
+            :title => "v2.1",
+            :state => "open",
+            :open_issues => 3,
+            :closed_issues => 1,
+            :due_on => "2030-12-01T08:00:00Z",
+          },
+          { :number => 9,
+            :title => "Backlog",
+            :state => "open",
+            :open_issues => 20,
+            :closed_issues => 0,
+            :due_on => nil,
+          },
+        ]
+      }
+      """
+    When I successfully run `hub milestone`
+    Then the output should contain exactly:
+      """
+        #12  v2.1  (3 open, 1 closed)  01 Dec 2030
+         #9  Backlog  (20 open, 0 closed)\n
+      """
+
+  Scenario: List all milestones with a custom format
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/milestones') {
+        assert :state => "all",
+               :sort => "completeness",
+               :direction => "asc"
+        json [
+          { :number => 3,
+            :title => "v1.0",
+            :state => "closed",
+            :open_issues => 0,
+            :closed_issues => 8,
+            :description => "First release",
+          },
+          { :number => 12,
+            :title => "v2.1",
+            :state => "open",
+            :open_issues => 3,
+            :closed_issues => 1,
+            :description => "",
+          },
+        ]
+      }
+      """
+    When I successfully run `hub milestone list -s all -o completeness -^ -f "%I %S %t: %p%% done%  b%n"`
+    Then the output should contain exactly:
+      """
+      3 closed v1.0: 100% done  First release
+      12 open v2.1: 25% done\n
+      """
+
+  Scenario: Create a milestone
+    Given the GitHub API server:
+      """
+      post('/repos/github/hub/milestones') {
+        assert :title => "v2.2",
+               :description => "Bug fixes",
+               :due_on => "2030-12-01T12:00:00Z"
+        status 201
+        json :number => 13,
+             :title => "v2.2",
+             :html_url => "https://github.com/github/hub/milestone/13"
+      }
+      """
+    When I successfully run `hub milestone create v2.2 --due 2030-12-01 -d "Bug fixes"`
+    Then the output should contain exactly:
+      """
+      https://github.com/github/hub/milestone/13\n
+      """
+
+  Scenario: Create a milestone with an invalid due date
+    When I run `hub milestone create v2.2 --due tomorrow`
+    Then the exit status should be 1
+    And the stderr should contain exactly "invalid due date: 'tomorrow' (expected YYYY-MM-DD)\n"
+
+  Scenario: Edit a milestone by title
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/milestones') {
+        assert :state => "all"
+        json [
+          { :number => 3, :title => "v1.0" },
+          { :number => 12, :title => "v2.1" },
+        ]
+      }
+      patch('/repos/github/hub/milestones/12') {
+        assert :title => "v2.1.0",
+               :due_on => nil,
+               :description => :no,
+               :state => :no
+        json :number => 12,
+          :html_url => "https://github.com/github/hub/milestone/12"
+      }
+      """
+    When I successfully run `hub milestone edit V2.1 -t v2.1.0 --due=`
+    Then the output should contain exactly:
+      """
+      https://github.com/github/hub/milestone/12\n
+      """
+
+  Scenario: Edit a milestone without specifying fields to update
+    When I run `hub milestone edit 12`
+    Then the exit status should be 1
+    And the stderr should contain "please specify fields to update"
+
+  Scenario: Milestone not found
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/milestones') {
+        json [
+          { :number => 12, :title => "v2.1" },
+        ]
+      }
+      """
+    When I run `hub milestone close v3.0`
+    Then the exit status should be 1
+    And the stderr should contain exactly "error: no milestone found with name 'v3.0'\n"
+
+  Scenario: Close a milestone
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/milestones') {
+        assert :state => "all"
+        json [{ :number => 12, :title => "v2.1" }]
+      }
+      patch('/repos/github/hub/milestones/12') {
+        assert :state => "closed"
+        json :number => 12,
+          :html_url => "https://github.com/github/hub/milestone/12"
+      }
+      """
+    When I successfully run `hub milestone close 12`
+    Then the output should contain exactly:
+      """
+      https://github.com/github/hub/milestone/12\n
+      """
+
+  Scenario: Delete a milestone
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/milestones') {
+        assert :state => "all"
+        json [{ :number => 12, :title => "v2.1" }]
+      }
+      delete('/repos/github/hub/milestones/12') {
+        status 204
+      }
+      """
+    When I successfully run `hub milestone delete 12`
+    Then the output should contain exactly ""
+
+  Scenario: Preview deleting a milestone
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/milestones') {
+        assert :state => "all"
+        json [{ :number => 12, :title => "v2.1" }]
+      }
+      """
+    When I successfully run `hub --noop milestone delete 12`
+    Then the output should contain exactly:
+      """
+      Would delete milestone #12 for github/hub\n
+      """
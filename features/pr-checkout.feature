@@ -80,3 +80,120 @@ Feature: hub pr checkout <PULLREQ-NUMBER>
     Then "git fetch origin +refs/heads/fixes:refs/remotes/origin/fixes" should be run
     And "git checkout -b fixes --no-track origin/fixes" should be run
     And "fixes" should merge "refs/heads/fixes" from remote "origin"
+
+  Scenario: Diverged local branch is checked out as a new version
+    Given the GitHub API server:
+      """
+      get('/repos/mojombo/jekyll/pulls/77') {
+        json :number => 77, :head => {
+          :ref => "fixes",
+          :repo => {
+            :name => "jekyll",
+            :owner => { :login => "mojombo" },
+          }
+        }, :base => {
+          :repo => {
+            :name => "jekyll",
+            :html_url => "https://github.com/mojombo/jekyll",
+            :owner => { :login => "mojombo" },
+          }
+        },
+        :html_url => 'https://github.com/mojombo/jekyll/pull/77'
+      }
+      """
+    Given I am on the "master" branch
+    And I am on the "fixes" branch
+    And I make a commit with message "Local work"
+    When I successfully run `git checkout --quiet master`
+    And I make a commit with message "Force-pushed work"
+    When I successfully run `hub pr checkout 77`
+    Then "git fetch origin +refs/heads/fixes:refs/remotes/origin/fixes" should be run
+    And the stderr should contain "Branch 'fixes' has diverged from the head of pull request #77:"
+    And the stderr should contain " Local work\n"
+    And the stderr should contain " Force-pushed work\n"
+    And the stderr should contain "Checking out the new head as 'fixes-v2'"
+    And "fixes-v2" should merge "refs/heads/fixes" from remote "origin"
+    When I successfully run `git rev-parse --abbrev-ref HEAD`
+    Then the output should contain exactly "fixes-v2\n"
+
+  Scenario: Reset diverged local branch
+    Given the GitHub API server:
+      """
+      get('/repos/mojombo/jekyll/pulls/77') {
+        json :number => 77, :head => {
+          :ref => "fixes",
+          :repo => {
+            :name => "jekyll",
+            :owner => { :login => "mojombo" },
+          }
+        }, :base => {
+          :repo => {
+            :name => "jekyll",
+            :html_url => "https://github.com/mojombo/jekyll",
+            :owner => { :login => "mojombo" },
+          }
+        },
+        :html_url => 'https://github.com/mojombo/jekyll/pull/77'
+      }
+      """
+    Given I am on the "master" branch
+    And I am on the "fixes" branch
+    And I make a commit with message "Local work"
+    When I successfully run `git checkout --quiet master`
+    And I make a commit with message "Force-pushed work"
+    When I successfully run `hub pr checkout 77 --force`
+    Then the stderr should contain "Resetting 'fixes' to "
+    When I successfully run `git log -1 --format=%s`
+    Then the output should contain exactly "Force-pushed work\n"
+    When I successfully run `git rev-parse --abbrev-ref HEAD`
+    Then the output should contain exactly "fixes\n"
+
+  Scenario: Check out in a new worktree
+    Given the GitHub API server:
+      """
+      get('/repos/mojombo/jekyll/pulls/77') {
+        json :number => 77, :head => {
+          :ref => "fixes",
+          :repo => {
+            :name => "jekyll",
+            :owner => { :login => "mojombo" },
+          }
+        }, :base => {
+          :repo => {
+            :name => "jekyll",
+            :html_url => "https://github.com/mojombo/jekyll",
+            :owner => { :login => "mojombo" },
+          }
+        },
+        :html_url => 'https://github.com/mojombo/jekyll/pull/77'
+      }
+      """
+    When I successfully run `hub pr checkout 77 --worktree=../jekyll-77`
+    Then "git fetch origin +refs/heads/fixes:refs/remotes/origin/fixes" should be run
+    And "git worktree add -b fixes --no-track ../jekyll-77 origin/fixes" should be run
+    And "fixes" should merge "refs/heads/fixes" from remote "origin"
+    And a directory named "../jekyll-77" should exist
+    When I successfully run `git config branch.fixes.hubPullRequest`
+    Then the output should contain "77\n"
+
+  Scenario: Remove worktrees of closed pull requests
+    Given I make a commit
+    And I successfully run `git worktree add --quiet -b fixes ../jekyll-77`
+    And I successfully run `git config branch.fixes.hubPullRequest 77`
+    And I successfully run `git worktree add --quiet -b features ../jekyll-78`
+    And I successfully run `git config branch.features.hubPullRequest 78`
+    And I successfully run `git worktree add --quiet -b local ../jekyll-local`
+    Given the GitHub API server:
+      """
+      get('/repos/mojombo/jekyll/pulls/77') {
+        json :number => 77, :state => "closed"
+      }
+      get('/repos/mojombo/jekyll/pulls/78') {
+        json :number => 78, :state => "open"
+      }
+      """
+    When I successfully run `hub pr checkout --cleanup`
+    Then the output should contain "of closed pull request #77\n"
+    And a directory named "../jekyll-77" should not exist
+    And a directory named "../jekyll-78" should exist
+    And a directory named "../jekyll-local" should exist
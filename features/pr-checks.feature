@@ -0,0 +1,151 @@
+Feature: hub pr checks
+  Background:
+    Given I am in "git://github.com/ashemesh/hub.git" git repo
+    And I am "ashemesh" on github.com with OAuth token "OTOKEN"
+
+  Scenario: Show checks for a pull request
+    Given the GitHub API server:
+      """
+      get('/repos/ashemesh/hub/pulls/12') {
+        json :number => 12,
+          :state => "open",
+          :head => { :ref => "feature", :sha => "abc123" }
+      }
+      get('/repos/ashemesh/hub/commits/abc123/status') {
+        json :state => "failure",
+          :statuses => [
+            { :state => "success",
+              :context => "continuous-integration/travis-ci/push",
+              :target_url => "https://travis-ci.org/ashemesh/hub/builds/1234567" },
+            { :state => "failure",
+              :context => "GitHub CLA",
+              :target_url => "https://cla.github.com/ashemesh/hub/accept/mislav" },
+          ]
+      }
+      get('/repos/ashemesh/hub/commits/abc123/check-runs') {
+        status 422
+      }
+      """
+    When I run `hub pr checks 12`
+    Then the output should contain exactly:
+      """
+      ✖︎	GitHub CLA                            	https://cla.github.com/ashemesh/hub/accept/mislav
+      ✔︎	continuous-integration/travis-ci/push 	https://travis-ci.org/ashemesh/hub/builds/1234567\n
+      """
+    And the exit status should be 1
+
+  Scenario: Pull request without checks
+    Given the GitHub API server:
+      """
+      get('/repos/ashemesh/hub/pulls/12') {
+        json :number => 12,
+          :state => "open",
+          :head => { :ref => "feature", :sha => "abc123" }
+      }
+      get('/repos/ashemesh/hub/commits/abc123/status') {
+        json :state => "pending", :statuses => []
+      }
+      get('/repos/ashemesh/hub/commits/abc123/check-runs') {
+        status 422
+      }
+      """
+    When I run `hub pr checks 12`
+    Then the output should contain exactly "no status\n"
+    And the exit status should be 3
+
+  Scenario: Watch checks until they finish
+    Given the GitHub API server:
+      """
+      get('/repos/ashemesh/hub/pulls/12') {
+        json :number => 12,
+          :state => "open",
+          :head => { :ref => "feature", :sha => "abc123" }
+      }
+      get('/repos/ashemesh/hub/commits/abc123/status') {
+        $checks_polled = ($checks_polled || 0) + 1
+        state = $checks_polled > 1 ? "success" : "pending"
+        json :state => state,
+          :statuses => [
+            { :state => state,
+              :context => "continuous-integration/travis-ci/push" },
+          ]
+      }
+      get('/repos/ashemesh/hub/commits/abc123/check-runs') {
+        status 422
+      }
+      """
+    When I run `hub pr checks 12 --watch --interval 1`
+    Then the output should contain exactly:
+      """
+      ●	continuous-integration/travis-ci/push
+
+      ✔︎	continuous-integration/travis-ci/push\n
+      """
+    And the exit status should be 0
+
+  Scenario: Keep watching until checks are reported
+    Given the GitHub API server:
+      """
+      get('/repos/ashemesh/hub/pulls/12') {
+        json :number => 12,
+          :state => "open",
+          :head => { :ref => "feature", :sha => "abc123" }
+      }
+      get('/repos/ashemesh/hub/commits/abc123/status') {
+        $checks_polled = ($checks_polled || 0) + 1
+        if $checks_polled > 1
+          json :state => "success",
+            :statuses => [
+              { :state => "success",
+                :context => "continuous-integration/travis-ci/push" },
+            ]
+        else
+          json :state => "pending", :statuses => []
+        end
+      }
+      get('/repos/ashemesh/hub/commits/abc123/check-runs') {
+        status 422
+      }
+      """
+    When I run `hub pr checks 12 --watch --interval 1`
+    Then the output should contain exactly:
+      """
+      no status
+
+      ✔︎	continuous-integration/travis-ci/push\n
+      """
+    And the exit status should be 0
+
+  Scenario: Stop watching checks on first failure
+    Given the GitHub API server:
+      """
+      get('/repos/ashemesh/hub/pulls/12') {
+        json :number => 12,
+          :state => "open",
+          :head => { :ref => "feature", :sha => "abc123" }
+      }
+      get('/repos/ashemesh/hub/commits/abc123/status') {
+        json :state => "failure",
+          :statuses => [
+            { :state => "pending",
+              :context => "continuous-integration/travis-ci/push" },
+            { :state => "failure",
+              :context => "GitHub CLA" },
+          ]
+      }
+      get('/repos/ashemesh/hub/commits/abc123/check-runs') {
+        status 422
+      }
+      """
+    When I run `hub pr checks 12 --watch --fail-fast`
+    Then the output should contain exactly:
+      """
+      ✖︎	GitHub CLA
+      ●	continuous-integration/travis-ci/push\n
+      """
+    And the exit status should be 1
+
+  Scenario: Invalid interval
+    When I run `hub pr checks 12 --watch --interval 0`
+    Then the stderr should contain "invalid interval: '0'"
+    And the exit status should be 1
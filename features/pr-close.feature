@@ -0,0 +1,140 @@
+Feature: hub pr close and hub pr reopen
+  Background:
+    Given I am in "git://github.com/friederbluemle/hub.git" git repo
+    And I am "friederbluemle" on github.com with OAuth token "OTOKEN"
+
+  Scenario: Close a pull request
+    Given the GitHub API server:
+      """
+      get('/repos/friederbluemle/hub/pulls/12') {
+        json :number => 12, :state => "open"
+      }
+      patch('/repos/friederbluemle/hub/pulls/12') {
+        assert :state => "closed"
+        json :number => 12, :state => "closed"
+      }
+      """
+    When I successfully run `hub pr close 12`
+    Then the output should contain exactly ""
+
+  Scenario: Close with a comment and delete the head branch
+    Given the GitHub API server:
+      """
+      get('/repos/friederbluemle/hub/pulls/12') {
+        json :number => 12,
+          :state => "open",
+          :base => {
+            :ref => "main",
+            :repo => { :name => "hub", :owner => { :login => "friederbluemle" } }
+          },
+          :head => {
+            :ref => "patch-1",
+            :repo => { :name => "hub", :owner => { :login => "friederbluemle" } }
+          }
+      }
+      post('/repos/friederbluemle/hub/issues/12/comments') {
+        assert :body => "Superseded by #13"
+        status 201
+        json :id => 1
+      }
+      patch('/repos/friederbluemle/hub/pulls/12') {
+        assert :state => "closed"
+        json :number => 12, :state => "closed"
+      }
+      delete('/repos/friederbluemle/hub/git/refs/heads/patch-1') {
+        status 204
+      }
+      """
+    When I successfully run `hub pr close -d -m "Superseded by #13" 12`
+    Then the output should contain exactly ""
+
+  Scenario: Close the pull request for the current branch
+    Given I am on the "topic" branch
+    Given the GitHub API server:
+      """
+      get('/repos/friederbluemle/hub/pulls') {
+        assert :state => "open",
+               :head => "friederbluemle:topic"
+        json [
+          { :number => 12, :state => "open" },
+        ]
+      }
+      patch('/repos/friederbluemle/hub/pulls/12') {
+        assert :state => "closed"
+        json :number => 12, :state => "closed"
+      }
+      """
+    When I successfully run `hub pr close`
+    Then the output should contain exactly ""
+
+  Scenario: Reopen the pull request for the current branch
+    Given I am on the "topic" branch
+    Given the GitHub API server:
+      """
+      get('/repos/friederbluemle/hub/pulls') {
+        assert :state => "closed",
+               :head => "friederbluemle:topic"
+        json [
+          { :number => 12, :state => "closed" },
+        ]
+      }
+      post('/repos/friederbluemle/hub/issues/12/comments') {
+        assert :body => "Let's try again"
+        status 201
+        json :id => 1
+      }
+      patch('/repos/friederbluemle/hub/pulls/12') {
+        assert :state => "open"
+        json :number => 12, :state => "open"
+      }
+      """
+    When I successfully run `hub pr reopen -m "Let's try again"`
+    Then the output should contain exactly ""
+
+  Scenario: Close an already closed pull request
+    Given the GitHub API server:
+      """
+      get('/repos/friederbluemle/hub/pulls/12') {
+        json :number => 12, :state => "closed"
+      }
+      """
+    When I successfully run `hub pr close -m "Superseded by #13" 12`
+    Then the stdout should contain exactly ""
+    And the stderr should contain exactly "Pull request #12 is already closed\n"
+
+  Scenario: Reopen an already open pull request
+    Given the GitHub API server:
+      """
+      get('/repos/friederbluemle/hub/pulls/12') {
+        json :number => 12, :state => "open"
+      }
+      """
+    When I successfully run `hub pr reopen 12`
+    Then the stdout should contain exactly ""
+    And the stderr should contain exactly "Pull request #12 is already open\n"
+
+  Scenario: Refuse to close a merged pull request
+    Given the GitHub API server:
+      """
+      get('/repos/friederbluemle/hub/pulls/12') {
+        json :number => 12,
+          :state => "closed",
+          :merged_at => "2018-04-06T10:00:00Z"
+      }
+      """
+    When I run `hub pr close 12`
+    Then the exit status should be 1
+    And the stderr should contain exactly "Error: pull request #12 is already merged\n"
+
+  Scenario: Refuse to preview closing a merged pull request
+    Given the GitHub API server:
+      """
+      get('/repos/friederbluemle/hub/pulls/12') {
+        json :number => 12,
+          :state => "closed",
+          :merged_at => "2018-04-06T10:00:00Z"
+      }
+      """
+    When I run `hub --noop pr close 12`
+    Then the exit status should be 1
+    And the stderr should contain exactly "Error: pull request #12 is already merged\n"
@@ -0,0 +1,58 @@
+Feature: hub pr comment
+  Background:
+    Given I am in "git://github.com/friederbluemle/hub.git" git repo
+    And I am "friederbluemle" on github.com with OAuth token "OTOKEN"
+
+  Scenario: Comment on a pull request
+    Given the GitHub API server:
+      """
+      get('/repos/friederbluemle/hub/pulls/12') {
+        json :number => 12, :state => "open"
+      }
+      post('/repos/friederbluemle/hub/issues/12/comments') {
+        assert :body => "Rebased on top of master"
+        status 201
+        json :id => 101, :html_url => "https://github.com/friederbluemle/hub/pull/12#issuecomment-101"
+      }
+      """
+    When I successfully run `hub pr comment 12 -m "Rebased on top of master"`
+    Then the output should contain exactly:
+      """
+      https://github.com/friederbluemle/hub/pull/12#issuecomment-101\n
+      """
+
+  Scenario: Comment on the pull request for the current branch
+    Given I am on the "topic" branch
+    Given the GitHub API server:
+      """
+      get('/repos/friederbluemle/hub/pulls') {
+        assert :state => "open",
+               :head => "friederbluemle:topic"
+        json [
+          { :number => 12, :state => "open" },
+        ]
+      }
+      post('/repos/friederbluemle/hub/issues/12/comments') {
+        assert :body => "Ready for another look"
+        status 201
+        json :id => 101, :html_url => "https://github.com/friederbluemle/hub/pull/12#issuecomment-101"
+      }
+      """
+    When I successfully run `hub pr comment -m "Ready for another look"`
+    Then the output should contain exactly:
+      """
+      https://github.com/friederbluemle/hub/pull/12#issuecomment-101\n
+      """
+
+  Scenario: Delete a pull request comment
+    Given the GitHub API server:
+      """
+      get('/repos/friederbluemle/hub/pulls/12') {
+        json :number => 12, :state => "open"
+      }
+      delete('/repos/friederbluemle/hub/issues/comments/100') {
+        status 204
+      }
+      """
+    When I successfully run `hub pr comment 12 --delete 100`
+    Then the output should contain exactly ""
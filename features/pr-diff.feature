@@ -0,0 +1,69 @@
+Feature: hub pr diff
+  Background:
+    Given I am in "git://github.com/friederbluemle/hub.git" git repo
+    And I am "friederbluemle" on github.com with OAuth token "OTOKEN"
+    Given the GitHub API server:
+      """
+      get('/repos/friederbluemle/hub/pulls/12'){
+        halt 400 unless request.env['HTTP_ACCEPT'] == 'application/vnd.github.v3.diff;charset=utf-8'
+        content_type "text/plain"
+        <<DIFF
+      diff --git a/README.md b/README.md
+      index 3d1f2a4..8c0e5b1 100644
+      --- a/README.md
+      +++ b/README.md
+      @@ -1,2 +1,2 @@
+       # hub
+      -Old intro
+      +New intro
+      diff --git a/main.go b/main.go
+      index 1234567..89abcde 100644
+      --- a/main.go
+      +++ b/main.go
+      @@ -10,0 +10,1 @@ func main() {
+      +os.Exit(0)
+      DIFF
+      }
+      """
+
+  Scenario: Print diff
+    When I successfully run `hub pr diff 12`
+    Then the output should contain exactly:
+      """
+      diff --git a/README.md b/README.md
+      index 3d1f2a4..8c0e5b1 100644
+      --- a/README.md
+      +++ b/README.md
+      @@ -1,2 +1,2 @@
+       # hub
+      -Old intro
+      +New intro
+      diff --git a/main.go b/main.go
+      index 1234567..89abcde 100644
+      --- a/main.go
+      +++ b/main.go
+      @@ -10,0 +10,1 @@ func main() {
+      +os.Exit(0)\n
+      """
+
+  Scenario: Print changed file names
+    When I successfully run `hub pr diff --name-only 12`
+    Then the output should contain exactly:
+      """
+      README.md
+      main.go\n
+      """
+
+  Scenario: Print diffstat
+    When I successfully run `hub pr diff --stat 12`
+    Then the output should contain exactly:
+      """
+       README.md | 2 +-
+       main.go   | 1 +
+       2 files changed, 2 insertions(+), 1 deletion(-)\n
+      """
+
+  Scenario: Conflicting output modes
+    When I run `hub pr diff --stat --name-only 12`
+    Then the exit status should be 1
+    And the stderr should contain "--name-only and --stat cannot be used together"
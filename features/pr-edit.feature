@@ -0,0 +1,97 @@
+Feature: hub pr edit
+  Background:
+    Given I am in "git://github.com/friederbluemle/hub.git" git repo
+    And I am "friederbluemle" on github.com with OAuth token "OTOKEN"
+
+  Scenario: Update title and description
+    Given the GitHub API server:
+      """
+      patch('/repos/friederbluemle/hub/pulls/12') {
+        assert :title => "New title",
+               :body => "New body",
+               :base => :no
+        json :number => 12
+      }
+      """
+    When I successfully run `hub pr edit 12 -m "New title" -m "New body"`
+    Then the output should contain exactly ""
+
+  Scenario: Update title and description in the text editor
+    Given the git commit editor is "vim"
+    And the text editor adds:
+      """
+      My new title
+      """
+    Given the GitHub API server:
+      """
+      get('/repos/friederbluemle/hub/pulls/12') {
+        json :number => 12,
+          :title => "My old title",
+          :body => "My old body"
+      }
+      patch('/repos/friederbluemle/hub/pulls/12') {
+        assert :title => "My new title",
+               :body => "My old title\n\nMy old body"
+        json :number => 12
+      }
+      """
+    When I successfully run `hub pr edit 12 --edit`
+    Then the output should contain exactly ""
+    And the file ".git/PULLREQ_EDITMSG" should not exist
+
+  Scenario: Change base branch
+    Given the GitHub API server:
+      """
+      patch('/repos/friederbluemle/hub/pulls/12') {
+        assert :base => "develop",
+               :title => :no,
+               :body => :no
+        json :number => 12
+      }
+      """
+    When I successfully run `hub pr edit 12 -b develop`
+    Then the output should contain exactly ""
+
+  Scenario: Update labels, assignees, and milestone
+    Given the GitHub API server:
+      """
+      get('/repos/friederbluemle/hub/milestones') {
+        json [
+          { :number => 237, :title => "prerelease" },
+          { :number => 42, :title => "Hello World!" }
+        ]
+      }
+      patch('/repos/friederbluemle/hub/issues/12') {
+        assert :labels => ["bug", "important"],
+               :assignees => ["mislav"],
+               :milestone => 42,
+               :title => :no
+      }
+      """
+    When I successfully run `hub pr edit 12 -l bug,important -a mislav -M "hello world!"`
+    Then the output should contain exactly ""
+
+  Scenario: Request reviewers
+    Given the GitHub API server:
+      """
+      get('/repos/friederbluemle/hub/pulls/12') {
+        json :number => 12,
+          :requested_reviewers => [
+            { :login => "josh" },
+          ],
+          :requested_teams => []
+      }
+      post('/repos/friederbluemle/hub/pulls/12/requested_reviewers') {
+        assert :reviewers => ["mislav"],
+               :team_reviewers => ["robots"]
+        status 201
+        json :number => 12
+      }
+      """
+    When I successfully run `hub pr edit 12 -r josh,mislav,github/robots`
+    Then the output should contain exactly ""
+
+  Scenario: No fields to update
+    When I run `hub pr edit 12`
+    Then the exit status should be 1
+    And the stderr should contain "please specify fields to update"
@@ -63,6 +63,61 @@ Feature: hub pr list
             #7  Fourth\n
       """
 
+  Scenario: List pulls with review state
+    Given the GitHub API server:
+    """
+    get('/repos/github/hub/pulls') {
+      json [
+        { :number => 102,
+          :title => "Second",
+          :state => "open",
+          :base => { :ref => "master", :label => "github:master" },
+          :head => { :ref => "patch-2", :label => "octocat:patch-2" },
+          :user => { :login => "octocat" },
+        },
+        { :number => 13,
+          :title => "Third",
+          :state => "open",
+          :base => { :ref => "master", :label => "github:master" },
+          :head => { :ref => "patch-3", :label => "octocat:patch-3" },
+          :user => { :login => "octocat" },
+        },
+      ]
+    }
+    post('/graphql') {
+      halt 400 if $graphql_queried
+      $graphql_queried = true
+      assert :query => /pr102: pullRequest\(number: 102\).+pr13: pullRequest\(number: 13\)/m,
+        :variables => { :owner => "github", :name => "hub" }
+      json :data => {
+        :repository => {
+          :pr102 => {
+            :latestOpinionatedReviews => { :nodes => [
+              { :state => "APPROVED", :author => { :login => "rey" } },
+              { :state => "CHANGES_REQUESTED", :author => { :login => "finn" } },
+            ] },
+            :reviewThreads => {
+              :nodes => [
+                { :path => "main.go", :line => 3, :isResolved => false },
+              ],
+              :pageInfo => { :hasNextPage => false }
+            }
+          },
+          :pr13 => {
+            :latestOpinionatedReviews => { :nodes => [] },
+            :reviewThreads => { :nodes => [], :pageInfo => { :hasNextPage => false } }
+          },
+        }
+      }
+    }
+    """
+    When I successfully run `hub pr list -f "%i approved:%ra changes:%rc unresolved:%rt%n"`
+    Then the output should contain exactly:
+      """
+      #102 approved:1 changes:finn unresolved:1
+      #13 approved:0 changes: unresolved:0\n
+      """
+
   Scenario: List pull requests with requested reviewers
     Given the GitHub API server:
     """
@@ -258,3 +313,146 @@ Feature: hub pr list
           #999  First
            #13  Third\n
       """
+
+  Scenario: Filter pull requests using search
+    Given the GitHub API server:
+    """
+    post('/graphql') {
+      assert :query => /search\(/,
+             :variables => {
+               :query => 'repo:github/hub is:pr is:open author:octocat assignee:@me label:bug label:"help wanted" draft:false sort:created-desc',
+               :perPage => 100,
+             }
+      json :data => {
+        :search => {
+          :nodes => [
+            { :number => 102,
+              :state => "OPEN",
+              :title => "Second",
+              :isDraft => false,
+              :author => { :login => "octocat" },
+              :labels => { :nodes => [
+                { :name => "bug", :color => "fc2929" },
+              ] },
+              :headRefName => "patch-2",
+              :headRepository => { :name => "hub", :owner => { :login => "octocat" } },
+              :baseRefName => "master",
+              :repository => { :name => "hub", :owner => { :login => "github" } },
+            },
+            { :number => 13,
+              :state => "MERGED",
+              :title => "Third",
+              :isDraft => false,
+              :mergedAt => "2018-04-07T12:34:56Z",
+              :author => { :login => "octocat" },
+              :headRefName => "patch-3",
+              :headRepository => { :name => "hub", :owner => { :login => "github" } },
+              :baseRefName => "master",
+              :repository => { :name => "hub", :owner => { :login => "github" } },
+            },
+          ],
+          :pageInfo => { :hasNextPage => false, :endCursor => "CURSOR" },
+        }
+      }
+    }
+    """
+    When I successfully run `hub pr list --author octocat --assignee @me --label "bug,help wanted" --no-draft -f "%I %pS %H %B%n"`
+    Then the output should contain exactly:
+      """
+      102 open octocat:patch-2 master
+      13 merged patch-3 master\n
+      """
+
+  Scenario: Search pull requests requesting review with pagination
+    Given the GitHub API server:
+    """
+    post('/graphql') {
+      if params[:variables][:endCursor] == "CURSOR"
+        json :data => {
+          :search => {
+            :nodes => [
+              { :number => 13,
+                :state => "OPEN",
+                :title => "Third",
+                :isDraft => true,
+                :headRefName => "patch-3",
+                :baseRefName => "master",
+                :repository => { :name => "hub", :owner => { :login => "github" } },
+              },
+            ],
+            :pageInfo => { :hasNextPage => false, :endCursor => "CURSOR2" },
+          }
+        }
+      else
+        assert :variables => {
+                 :query => "repo:github/hub is:pr review-requested:@me draft:true sort:updated-asc fix in:title",
+                 :perPage => 100,
+               }
+        json :data => {
+          :search => {
+            :nodes => [
+              { :number => 102,
+                :state => "OPEN",
+                :title => "Second",
+                :isDraft => true,
+                :headRefName => "patch-2",
+                :baseRefName => "master",
+                :repository => { :name => "hub", :owner => { :login => "github" } },
+              },
+            ],
+            :pageInfo => { :hasNextPage => true, :endCursor => "CURSOR" },
+          }
+        }
+      end
+    }
+    """
+    When I successfully run `hub pr list -s all --review-requested @me --draft -o updated -^ --search "fix in:title"`
+    Then the output should contain exactly:
+      """
+          #102  Second
+           #13  Third\n
+      """
+
+  Scenario: Search pull requests requesting review from a team by head
+    Given the GitHub API server:
+    """
+    post('/graphql') {
+      assert :variables => {
+               :query => "repo:github/hub is:pr is:open head:patch-1 team-review-requested:github/core sort:created-desc",
+               :perPage => 100,
+             }
+      json :data => {
+        :search => {
+          :nodes => [
+            { :number => 102,
+              :state => "OPEN",
+              :title => "Second",
+              :headRefName => "patch-1",
+              :headRepository => { :name => "hub", :owner => { :login => "octocat" } },
+              :baseRefName => "master",
+              :repository => { :name => "hub", :owner => { :login => "github" } },
+            },
+            { :number => 13,
+              :state => "OPEN",
+              :title => "Third",
+              :headRefName => "patch-1",
+              :headRepository => { :name => "hub", :owner => { :login => "mislav" } },
+              :baseRefName => "master",
+              :repository => { :name => "hub", :owner => { :login => "github" } },
+            },
+          ],
+          :pageInfo => { :hasNextPage => false, :endCursor => "CURSOR" },
+        }
+      }
+    }
+    """
+    When I successfully run `hub pr list --review-requested github/core --head mislav:patch-1`
+    Then the output should contain exactly:
+      """
+           #13  Third\n
+      """
+
+  Scenario: Unsupported sort key when searching
+    When I run `hub pr list --author octocat -o long-running`
+    Then the stderr should contain "sorting by 'long-running' is not supported when searching"
+    And the exit status should be 1
@@ -213,3 +213,347 @@ Feature: hub pr merge
       """
     When I successfully run `hub pr merge -d 12`
     Then the output should contain exactly ""
+
+  Scenario: Enable auto-merge
+    Given the GitHub API server:
+      """
+      post('/graphql') {
+        if params[:query] =~ /isMergeQueueEnabled/
+          assert :variables => { :owner => "friederbluemle", :name => "hub", :number => 12 }
+          json :data => {
+            :repository => { :pullRequest => {
+              :id => "PR_NODE",
+              :url => "https://github.com/friederbluemle/hub/pull/12",
+              :isMergeQueueEnabled => false,
+              :isInMergeQueue => false
+            } }
+          }
+        else
+          assert :query => /enablePullRequestAutoMerge/,
+            :variables => { :id => "PR_NODE", :method => "SQUASH" }
+          json :data => {
+            :enablePullRequestAutoMerge => { :clientMutationId => nil }
+          }
+        end
+      }
+      """
+    When I successfully run `hub pr merge --auto --squash 12`
+    Then the output should contain exactly:
+      """
+      https://github.com/friederbluemle/hub/pull/12\n
+      """
+
+  Scenario: Enable auto-merge with commit message
+    Given the GitHub API server:
+      """
+      post('/graphql') {
+        if params[:query] =~ /isMergeQueueEnabled/
+          assert :variables => { :owner => "friederbluemle", :name => "hub", :number => 12 }
+          json :data => {
+            :repository => { :pullRequest => {
+              :id => "PR_NODE",
+              :url => "https://github.com/friederbluemle/hub/pull/12",
+              :isMergeQueueEnabled => false,
+              :isInMergeQueue => false
+            } }
+          }
+        else
+          assert :query => /enablePullRequestAutoMerge/,
+            :variables => {
+              :id => "PR_NODE",
+              :method => "MERGE",
+              :headline => "mytitle",
+              :body => "mybody",
+              :sha => "MYSHA",
+            }
+          json :data => {
+            :enablePullRequestAutoMerge => { :clientMutationId => nil }
+          }
+        end
+      }
+      """
+    When I successfully run `hub pr merge --auto 12 -m mytitle -m mybody --head-sha MYSHA`
+    Then the output should contain exactly:
+      """
+      https://github.com/friederbluemle/hub/pull/12\n
+      """
+
+  Scenario: Disable auto-merge
+    Given the GitHub API server:
+      """
+      post('/graphql') {
+        if params[:query] =~ /isMergeQueueEnabled/
+          assert :variables => { :owner => "friederbluemle", :name => "hub", :number => 12 }
+          json :data => {
+            :repository => { :pullRequest => {
+              :id => "PR_NODE",
+              :url => "https://github.com/friederbluemle/hub/pull/12",
+              :isMergeQueueEnabled => false,
+              :isInMergeQueue => false
+            } }
+          }
+        else
+          assert :query => /disablePullRequestAutoMerge/,
+            :variables => { :id => "PR_NODE" }
+          json :data => {
+            :disablePullRequestAutoMerge => { :clientMutationId => nil }
+          }
+        end
+      }
+      """
+    When I successfully run `hub pr merge --disable-auto 12`
+    Then the output should contain exactly:
+      """
+      https://github.com/friederbluemle/hub/pull/12\n
+      """
+
+  Scenario: Preview disabling auto-merge
+    Given the GitHub API server:
+      """
+      post('/graphql') {
+        if params[:query] =~ /isMergeQueueEnabled/
+          assert :variables => { :owner => "friederbluemle", :name => "hub", :number => 12 }
+          json :data => {
+            :repository => { :pullRequest => {
+              :id => "PR_NODE",
+              :url => "https://github.com/friederbluemle/hub/pull/12",
+              :isMergeQueueEnabled => false,
+              :isInMergeQueue => false
+            } }
+          }
+        else
+          halt 400, "should not change auto-merge"
+        end
+      }
+      """
+    When I successfully run `hub --noop pr merge --disable-auto 12`
+    Then the output should contain exactly:
+      """
+      Would disable auto-merge for pull request #12 for friederbluemle/hub\n
+      """
+
+  Scenario: Add a pull request to the merge queue
+    Given the GitHub API server:
+      """
+      post('/graphql') {
+        if params[:query] =~ /isMergeQueueEnabled/
+          assert :variables => { :owner => "friederbluemle", :name => "hub", :number => 12 }
+          json :data => {
+            :repository => { :pullRequest => {
+              :id => "PR_NODE",
+              :url => "https://github.com/friederbluemle/hub/pull/12",
+              :isMergeQueueEnabled => true,
+              :isInMergeQueue => false
+            } }
+          }
+        else
+          assert :query => /enqueuePullRequest/,
+            :variables => { :id => "PR_NODE", :sha => "MYSHA" }
+          json :data => {
+            :enqueuePullRequest => { :clientMutationId => nil }
+          }
+        end
+      }
+      """
+    When I successfully run `hub pr merge --auto 12 --head-sha MYSHA`
+    Then the output should contain exactly:
+      """
+      https://github.com/friederbluemle/hub/pull/12\n
+      """
+
+  Scenario: Merge queue decides the merge method
+    Given the GitHub API server:
+      """
+      post('/graphql') {
+        if params[:query] =~ /isMergeQueueEnabled/
+          assert :variables => { :owner => "friederbluemle", :name => "hub", :number => 12 }
+          json :data => {
+            :repository => { :pullRequest => {
+              :id => "PR_NODE",
+              :url => "https://github.com/friederbluemle/hub/pull/12",
+              :isMergeQueueEnabled => true,
+              :isInMergeQueue => false
+            } }
+          }
+        else
+          halt 400, "should not enqueue"
+        end
+      }
+      """
+    When I run `hub pr merge --auto --squash 12`
+    Then the exit status should be 1
+    And the stderr should contain exactly "Error: the base branch of pull request #12 uses a merge queue, which decides how pull requests get merged\n"
+
+  Scenario: Remove a pull request from the merge queue
+    Given the GitHub API server:
+      """
+      post('/graphql') {
+        if params[:query] =~ /isMergeQueueEnabled/
+          assert :variables => { :owner => "friederbluemle", :name => "hub", :number => 12 }
+          json :data => {
+            :repository => { :pullRequest => {
+              :id => "PR_NODE",
+              :url => "https://github.com/friederbluemle/hub/pull/12",
+              :isMergeQueueEnabled => true,
+              :isInMergeQueue => true
+            } }
+          }
+        else
+          assert :query => /dequeuePullRequest/,
+            :variables => { :id => "PR_NODE" }
+          json :data => {
+            :dequeuePullRequest => { :clientMutationId => nil }
+          }
+        end
+      }
+      """
+    When I successfully run `hub pr merge --disable-auto 12`
+    Then the output should contain exactly:
+      """
+      https://github.com/friederbluemle/hub/pull/12\n
+      """
+
+  Scenario: Auto-merge does not delete the branch
+    When I run `hub pr merge --auto -d 12`
+    Then the stderr should contain "--delete-branch cannot be used with auto-merge"
+    And the exit status should be 1
+
+  Scenario: Check that a pull request can be merged
+    Given the GitHub API server:
+      """
+      get('/repos/friederbluemle/hub/pulls/12') {
+        json :number => 12, :state => "open",
+          :mergeable => true, :mergeable_state => "clean",
+          :head => { :ref => "feature", :sha => "HEADSHA" },
+          :base => { :ref => "master" }
+      }
+      get('/repos/friederbluemle/hub/branches/master/protection') {
+        status 404
+        json :message => "Branch not protected"
+      }
+      get('/repos/friederbluemle/hub/pulls/12/reviews') {
+        json []
+      }
+      put('/repos/friederbluemle/hub/pulls/12/merge') {
+        halt 400, "should not merge"
+      }
+      """
+    When I successfully run `hub pr merge --check 12`
+    Then the output should contain exactly "Pull request #12 can be merged\n"
+
+  Scenario: Check reports what blocks the merge
+    Given the GitHub API server:
+      """
+      get('/repos/friederbluemle/hub/pulls/12') {
+        json :number => 12, :state => "open",
+          :mergeable => true, :mergeable_state => "blocked",
+          :head => { :ref => "feature", :sha => "HEADSHA" },
+          :base => { :ref => "master" }
+      }
+      get('/repos/friederbluemle/hub/branches/master/protection') {
+        json :required_status_checks => { :strict => true, :contexts => ["ci/build"] },
+          :required_pull_request_reviews => { :required_approving_review_count => 1 }
+      }
+      get('/repos/friederbluemle/hub/pulls/12/reviews') {
+        json [
+          { :state => "CHANGES_REQUESTED", :user => { :login => "mislav" } },
+        ]
+      }
+      get('/repos/friederbluemle/hub/commits/HEADSHA/status') {
+        json :state => "pending", :statuses => [
+          { :context => "ci/build", :state => "pending" },
+        ]
+      }
+      get('/repos/friederbluemle/hub/commits/HEADSHA/check-runs') {
+        json :check_runs => []
+      }
+      """
+    When I run `hub pr merge --check 12`
+    Then the exit status should be 3
+    And the output should contain exactly:
+      """
+      Pull request #12 cannot be merged:
+        - changes were requested by mislav
+        - 0 of 1 required approving reviews
+        - required check 'ci/build' is pending\n
+      """
+
+  Scenario: Check honors required checks without contexts
+    Given the GitHub API server:
+      """
+      get('/repos/friederbluemle/hub/pulls/12') {
+        json :number => 12, :state => "open",
+          :mergeable => true, :mergeable_state => "clean",
+          :head => { :ref => "feature", :sha => "HEADSHA" },
+          :base => { :ref => "master" }
+      }
+      get('/repos/friederbluemle/hub/branches/master/protection') {
+        json :required_status_checks => {
+          :strict => false,
+          :contexts => [],
+          :checks => [{ :context => "ci/build", :app_id => 123 }]
+        }
+      }
+      get('/repos/friederbluemle/hub/pulls/12/reviews') {
+        json []
+      }
+      get('/repos/friederbluemle/hub/commits/HEADSHA/status') {
+        json :state => "pending", :statuses => []
+      }
+      get('/repos/friederbluemle/hub/commits/HEADSHA/check-runs') {
+        json :check_runs => [
+          { :name => "ci/build", :status => "completed", :conclusion => "success" },
+        ]
+      }
+      """
+    When I successfully run `hub pr merge --check 12`
+    Then the output should contain exactly "Pull request #12 can be merged\n"
+
+  Scenario: Check without access to branch protection
+    Given the GitHub API server:
+      """
+      get('/repos/friederbluemle/hub/pulls/12') {
+        json :number => 12, :state => "open",
+          :mergeable => true, :mergeable_state => "blocked",
+          :head => { :ref => "feature", :sha => "HEADSHA" },
+          :base => { :ref => "master" }
+      }
+      get('/repos/friederbluemle/hub/branches/master/protection') {
+        status 404
+        json :message => "Not Found"
+      }
+      get('/repos/friederbluemle/hub/pulls/12/reviews') {
+        json []
+      }
+      """
+    When I run `hub pr merge --check 12`
+    Then the exit status should be 7
+    And the output should contain exactly:
+      """
+      Pull request #12 has no known blockers, but the branch protection rules of master could not be read\n
+      """
+
+  Scenario: Check reports merge conflicts
+    Given the GitHub API server:
+      """
+      get('/repos/friederbluemle/hub/pulls/12') {
+        json :number => 12, :state => "open",
+          :mergeable => false, :mergeable_state => "dirty",
+          :head => { :ref => "feature", :sha => "HEADSHA" },
+          :base => { :ref => "master" }
+      }
+      get('/repos/friederbluemle/hub/branches/master/protection') {
+        status 404
+        json :message => "Branch not protected"
+      }
+      get('/repos/friederbluemle/hub/pulls/12/reviews') {
+        json []
+      }
+      """
+    When I run `hub pr merge --check 12`
+    Then the exit status should be 2
+    And the output should contain exactly:
+      """
+      Pull request #12 cannot be merged:
+        - there are merge conflicts with master\n
+      """
@@ -0,0 +1,96 @@
+Feature: hub pr ready and hub pr draft
+  Background:
+    Given I am in "git://github.com/friederbluemle/hub.git" git repo
+    And I am "friederbluemle" on github.com with OAuth token "OTOKEN"
+
+  Scenario: Mark pull request as ready for review
+    Given the GitHub API server:
+      """
+      get('/repos/friederbluemle/hub/pulls/12') {
+        json :number => 12,
+          :node_id => "PR_NODE",
+          :draft => true,
+          :html_url => "https://github.com/friederbluemle/hub/pull/12"
+      }
+      post('/graphql') {
+        assert :query => /markPullRequestReadyForReview/,
+          :variables => { :id => "PR_NODE" }
+        json :data => {
+          :markPullRequestReadyForReview => { :pullRequest => { :isDraft => false } }
+        }
+      }
+      """
+    When I successfully run `hub pr ready 12`
+    Then the output should contain exactly "https://github.com/friederbluemle/hub/pull/12\n"
+
+  Scenario: Pull request is already ready for review
+    Given the GitHub API server:
+      """
+      get('/repos/friederbluemle/hub/pulls/12') {
+        json :number => 12,
+          :node_id => "PR_NODE",
+          :draft => false
+      }
+      """
+    When I successfully run `hub pr ready 12`
+    Then the stdout should contain exactly ""
+    And the stderr should contain exactly "Pull request #12 is already ready for review\n"
+
+  Scenario: Pull request is already a draft
+    Given the GitHub API server:
+      """
+      get('/repos/friederbluemle/hub/pulls/12') {
+        json :number => 12,
+          :node_id => "PR_NODE",
+          :draft => true
+      }
+      """
+    When I successfully run `hub pr draft 12`
+    Then the stdout should contain exactly ""
+    And the stderr should contain exactly "Pull request #12 is already a draft\n"
+
+  Scenario: Convert pull request for the current branch to draft
+    Given I am on the "topic" branch
+    Given the GitHub API server:
+      """
+      get('/repos/friederbluemle/hub/pulls') {
+        assert :state => "open",
+               :head => "friederbluemle:topic"
+        json [
+          { :number => 12,
+            :node_id => "PR_NODE",
+            :draft => false,
+            :html_url => "https://github.com/friederbluemle/hub/pull/12" },
+        ]
+      }
+      post('/graphql') {
+        assert :query => /convertPullRequestToDraft/,
+          :variables => { :id => "PR_NODE" }
+        json :data => {
+          :convertPullRequestToDraft => { :pullRequest => { :isDraft => true } }
+        }
+      }
+      """
+    When I successfully run `hub pr draft`
+    Then the output should contain exactly "https://github.com/friederbluemle/hub/pull/12\n"
+
+  Scenario: GraphQL error
+    Given the GitHub API server:
+      """
+      get('/repos/friederbluemle/hub/pulls/12') {
+        json :number => 12,
+          :node_id => "PR_NODE",
+          :draft => false
+      }
+      post('/graphql') {
+        json :errors => [
+          { :message => "Pull request cannot be converted to draft" },
+        ]
+      }
+      """
+    When I run `hub pr draft 12`
+    Then the exit status should be 1
+    And the stderr should contain exactly:
+      """
+      API error: Pull request cannot be converted to draft\n
+      """
@@ -0,0 +1,179 @@
+Feature: hub pr revert
+  Background:
+    Given I am in "git://github.com/mislav/coral.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+
+  Scenario: Revert a squashed pull request
+    Given I am on the "master" branch
+    And I successfully run `git checkout -q -b feature`
+    And a file named "feature.txt" with:
+      """
+      start
+      """
+    And I successfully run `git add feature.txt`
+    And I successfully run `git commit -q -m "Start feature"`
+    And a file named "feature.txt" with:
+      """
+      continue
+      """
+    And I successfully run `git add feature.txt`
+    And I successfully run `git commit -q -m "Continue feature"`
+    And a file named "feature.txt" with:
+      """
+      finish
+      """
+    And I successfully run `git add feature.txt`
+    And I successfully run `git commit -q -m "Finish feature"`
+    And I successfully run `git checkout -q master`
+    And I make a commit with message "Unrelated work"
+    And I make a commit with message "More unrelated work"
+    And I successfully run `git merge -q --squash feature`
+    And I successfully run `git commit -q -m "Add feature (#12)"`
+    And the "master" branch is pushed to "origin/master"
+    Given the GitHub API server:
+      """
+      feature_sha = cd('.') { `git rev-parse feature`.chomp }
+      get('/repos/mislav/coral/pulls/12') {
+        json :number => 12,
+          :title => "Add feature",
+          :state => "closed",
+          :merged_at => "2020-01-01T00:00:00Z",
+          :merge_commit_sha => "origin/master",
+          :commits => 3,
+          :head => { :ref => "feature", :sha => feature_sha, :label => "mislav:feature" },
+          :base => { :ref => "master", :label => "mislav:master" }
+      }
+      post('/repos/mislav/coral/pulls') {
+        assert :base => "master",
+               :head => "mislav:revert-12-feature",
+               :title => 'Revert "Add feature"',
+               :body => "Reverts #12",
+               :draft => true
+        status 201
+        json :html_url => "https://github.com/mislav/coral/pull/13"
+      }
+      """
+    When I successfully run `hub pr revert 12 --draft`
+    Then the output should contain exactly "https://github.com/mislav/coral/pull/13\n"
+    And "git fetch origin master" should be run
+    And "git fetch origin refs/pull/12/head" should be run
+    And "git push --set-upstream origin HEAD:refs/heads/revert-12-feature" should be run
+    When I successfully run `git log -1 --format=%D%n%s`
+    Then the output should contain exactly:
+      """
+      HEAD -> revert-12-feature
+      Revert "Add feature (#12)"\n
+      """
+
+  Scenario: Revert a rebased pull request with reworded commits
+    Given I am on the "master" branch
+    And I successfully run `git checkout -q -b widget`
+    And a file named "widget.txt" with:
+      """
+      widget
+      """
+    And I successfully run `git add widget.txt`
+    And I successfully run `git commit -q -m "Add widget"`
+    And a file named "README.md" with:
+      """
+      widget docs
+      """
+    And I successfully run `git add README.md`
+    And I successfully run `git commit -q -m "Document widget"`
+    And I successfully run `git checkout -q master`
+    And I make a commit with message "Unrelated work"
+    And I successfully run `git cherry-pick widget~2..widget`
+    And I successfully run `git commit -q --amend -m "Document the widget in the README, wrapping a long subject"`
+    And the "master" branch is pushed to "origin/master"
+    Given the GitHub API server:
+      """
+      widget_sha = cd('.') { `git rev-parse widget`.chomp }
+      get('/repos/mislav/coral/pulls/12') {
+        json :number => 12,
+          :title => "Add widget",
+          :state => "closed",
+          :merged_at => "2020-01-01T00:00:00Z",
+          :merge_commit_sha => "origin/master",
+          :commits => 2,
+          :head => { :ref => "widget", :sha => widget_sha, :label => "mislav:widget" },
+          :base => { :ref => "master", :label => "mislav:master" }
+      }
+      post('/repos/mislav/coral/pulls') {
+        assert :head => "mislav:revert-12-widget"
+        status 201
+        json :html_url => "https://github.com/mislav/coral/pull/13"
+      }
+      """
+    When I successfully run `hub pr revert 12`
+    Then the output should contain exactly "https://github.com/mislav/coral/pull/13\n"
+    When I successfully run `git log -3 --format=%s`
+    Then the output should contain exactly:
+      """
+      Revert "Add widget"
+      Revert "Document the widget in the README, wrapping a long subject"
+      Document the widget in the README, wrapping a long subject\n
+      """
+
+  Scenario: Refuse to guess which commits to revert
+    Given I am on the "master" branch
+    And I successfully run `git checkout -q -b widget`
+    And a file named "widget.txt" with:
+      """
+      widget
+      """
+    And I successfully run `git add widget.txt`
+    And I successfully run `git commit -q -m "Add widget"`
+    And a file named "README.md" with:
+      """
+      widget docs
+      """
+    And I successfully run `git add README.md`
+    And I successfully run `git commit -q -m "Document widget"`
+    And I successfully run `git checkout -q master`
+    And I make a commit with message "Unrelated work"
+    And I successfully run `git cherry-pick widget`
+    And the "master" branch is pushed to "origin/master"
+    Given the GitHub API server:
+      """
+      widget_sha = cd('.') { `git rev-parse widget`.chomp }
+      get('/repos/mislav/coral/pulls/12') {
+        json :number => 12,
+          :title => "Add widget",
+          :state => "closed",
+          :merged_at => "2020-01-01T00:00:00Z",
+          :merge_commit_sha => "origin/master",
+          :commits => 2,
+          :head => { :ref => "widget", :sha => widget_sha, :label => "mislav:widget" },
+          :base => { :ref => "master", :label => "mislav:master" }
+      }
+      post('/repos/mislav/coral/pulls') {
+        halt 400, "should not open a pull request"
+      }
+      """
+    When I run `hub pr revert 12`
+    Then the exit status should be 1
+    And the stderr should contain "Error: could not tell how pull request #12 was merged: 1 of the 2 commits leading up to origin/master match the pull request commits"
+    And "git push --set-upstream origin HEAD:refs/heads/revert-12-widget" should not be run
+
+  Scenario: Pull request was not merged
+    Given the GitHub API server:
+      """
+      get('/repos/mislav/coral/pulls/12') {
+        json :number => 12,
+          :state => "closed",
+          :merge_commit_sha => "abc123",
+          :head => { :ref => "feature", :label => "mislav:feature" },
+          :base => { :ref => "master", :label => "mislav:master" }
+      }
+      """
+    When I run `hub pr revert 12`
+    Then the exit status should be 1
+    And the stderr should contain exactly:
+      """
+      Error: pull request #12 has not been merged\n
+      """
+
+  Scenario: No pull request number given
+    When I run `hub pr revert`
+    Then the exit status should be 1
+    And the stderr should contain exactly "Error: No pull request number given\n"
@@ -0,0 +1,100 @@
+Feature: hub pr review
+  Background:
+    Given I am in "git://github.com/friederbluemle/hub.git" git repo
+    And I am "friederbluemle" on github.com with OAuth token "OTOKEN"
+
+  Scenario: Approve
+    Given the GitHub API server:
+      """
+      post('/repos/friederbluemle/hub/pulls/12/reviews'){
+        assert :event => "APPROVE",
+               :body => :no,
+               :comments => :no
+
+        json :id => 80,
+          :state => "APPROVED",
+          :html_url => "https://github.com/friederbluemle/hub/pull/12#pullrequestreview-80"
+      }
+      """
+    When I successfully run `hub pr review 12 --approve`
+    Then the output should contain exactly:
+      """
+      https://github.com/friederbluemle/hub/pull/12#pullrequestreview-80\n
+      """
+
+  Scenario: Request changes with message
+    Given the GitHub API server:
+      """
+      post('/repos/friederbluemle/hub/pulls/12/reviews'){
+        assert :event => "REQUEST_CHANGES",
+               :body => "Needs tests\n\nAnd docs"
+
+        json :id => 80,
+          :html_url => "https://github.com/friederbluemle/hub/pull/12#pullrequestreview-80"
+      }
+      """
+    When I successfully run `hub pr review 12 --request-changes -m "Needs tests" -m "And docs"`
+    Then the output should contain exactly:
+      """
+      https://github.com/friederbluemle/hub/pull/12#pullrequestreview-80\n
+      """
+
+  Scenario: Comment from the text editor
+    Given the git commit editor is "vim"
+    And the text editor adds:
+      """
+      Looks promising
+      """
+    Given the GitHub API server:
+      """
+      post('/repos/friederbluemle/hub/pulls/12/reviews'){
+        assert :event => "COMMENT",
+               :body => "Looks promising"
+
+        json :id => 80,
+          :html_url => "https://github.com/friederbluemle/hub/pull/12#pullrequestreview-80"
+      }
+      """
+    When I successfully run `hub pr review 12 --comment`
+    Then the output should contain exactly:
+      """
+      https://github.com/friederbluemle/hub/pull/12#pullrequestreview-80\n
+      """
+    And the file ".git/PULLREQ_REVIEW_EDITMSG" should not exist
+
+  Scenario: Inline comments
+    Given the GitHub API server:
+      """
+      post('/repos/friederbluemle/hub/pulls/12/reviews'){
+        assert :event => "COMMENT",
+               :body => "A few nits",
+               :comments => [
+                 { "path" => "README.md", "line" => 7, "side" => "RIGHT", "body" => "typo" },
+                 { "path" => "main.go", "line" => 12, "side" => "RIGHT", "body" => "unused: remove" },
+                 { "path" => "main.go", "line" => 20, "side" => "LEFT", "body" => "still needed" },
+               ]
+
+        json :id => 80,
+          :html_url => "https://github.com/friederbluemle/hub/pull/12#pullrequestreview-80"
+      }
+      """
+    When I successfully run `hub pr review 12 --comment -m "A few nits" --inline README.md:7:typo --inline "main.go:12:unused: remove" --inline "main.go:-20:still needed"`
+    Then the output should contain exactly:
+      """
+      https://github.com/friederbluemle/hub/pull/12#pullrequestreview-80\n
+      """
+
+  Scenario: Missing review event
+    When I run `hub pr review 12 -m "LGTM"`
+    Then the exit status should be 1
+    And the stderr should contain "please specify --approve, --request-changes, or --comment"
+
+  Scenario: Conflicting review events
+    When I run `hub pr review 12 --approve --request-changes`
+    Then the exit status should be 1
+    And the stderr should contain "only one of --approve, --request-changes, or --comment can be given"
+
+  Scenario: Empty review message
+    When I run `hub pr review 12 --request-changes -m ""`
+    Then the exit status should be 1
+    And the stderr should contain exactly "Aborting review due to empty message\n"
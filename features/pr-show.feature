@@ -218,3 +218,85 @@ Feature: hub pr show
       """
       invalid pull request number: 'XYZ'\n
       """
+
+  Scenario: Format review state
+    Given the GitHub API server:
+      """
+      get('/repos/ashemesh/hub/pulls/102') {
+        json :number => 102,
+          :state => "open",
+          :base => { :ref => "master", :label => "github:master" },
+          :head => { :ref => "patch-1", :label => "octocat:patch-1" },
+          :user => { :login => "octocat" }
+      }
+      post('/graphql') {
+        assert :query => /latestOpinionatedReviews/,
+          :variables => { :owner => "ashemesh", :name => "hub", :number => 102 }
+        json :data => {
+          :repository => { :pullRequest => {
+            :latestOpinionatedReviews => { :nodes => [
+              { :state => "APPROVED", :author => { :login => "rey" } },
+              { :state => "CHANGES_REQUESTED", :author => { :login => "finn" } },
+              { :state => "APPROVED", :author => { :login => "poe" } },
+            ] },
+            :reviewThreads => {
+              :nodes => [
+                { :path => "main.go", :line => 3, :isResolved => true, :comments => { :nodes => [] } },
+                { :path => "main.go", :line => 9, :isResolved => false, :comments => { :nodes => [] } },
+              ],
+              :pageInfo => { :hasNextPage => false }
+            }
+          } }
+        }
+      }
+      """
+    When I successfully run `hub pr show 102 -f "%i approved:%ra changes:%rc unresolved:%rt"`
+    Then the output should contain exactly:
+      """
+      #102 approved:2 changes:finn unresolved:1\n
+      """
+
+  Scenario: Show review threads
+    Given the GitHub API server:
+      """
+      post('/graphql') {
+        json :data => {
+          :repository => { :pullRequest => {
+            :latestOpinionatedReviews => { :nodes => [] },
+            :reviewThreads => {
+              :nodes => [
+                { :path => "commands/pr.go", :line => 42, :isResolved => false,
+                  :comments => { :nodes => [
+                    { :body => "Why not a constant?", :author => { :login => "rey" },
+                      :diffHunk => "@@ -38,5 +38,5 @@ func pr() {\n \tif err != nil {\n \t\treturn\n \t}\n-\ttimeout := 10\n+\ttimeout := 30" },
+                    { :body => "Good point.\r\nWill fix.", :author => { :login => "octocat" } },
+                  ] } },
+                { :path => "README.md", :line => nil, :originalLine => 7, :isResolved => true, :isOutdated => true,
+                  :comments => { :nodes => [
+                    { :body => "Typo", :author => { :login => "finn" } },
+                  ] } },
+              ],
+              :pageInfo => { :hasNextPage => false }
+            }
+          } }
+        }
+      }
+      """
+    When I successfully run `hub pr show --comments 102`
+    Then the output should contain exactly:
+      """
+      commands/pr.go:42
+         		return
+         	}
+        -	timeout := 10
+        +	timeout := 30
+        rey:
+          Why not a constant?
+        octocat:
+          Good point.
+          Will fix.
+
+      README.md:7 (resolved, outdated)
+        finn:
+          Typo\n
+      """
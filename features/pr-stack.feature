@@ -0,0 +1,106 @@
+Feature: hub pr stack
+  Background:
+    Given I am in "git://github.com/mislav/dotfiles.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+    And I make a commit
+    And I successfully run `git checkout --quiet -b feature-a`
+    And I make a commit with message "Add feature A"
+    And I successfully run `git config branch.feature-a.remote .`
+    And I successfully run `git config branch.feature-a.merge refs/heads/master`
+    And I successfully run `git checkout --quiet -b feature-b`
+    And I make a commit with message "Add feature B"
+    And I successfully run `git config branch.feature-b.remote .`
+    And I successfully run `git config branch.feature-b.merge refs/heads/feature-a`
+
+  Scenario: Open pull requests for a stack of branches
+    Given the GitHub API server:
+      """
+      get('/repos/mislav/dotfiles/pulls') {
+        assert :state => "all"
+        json []
+      }
+      post('/repos/mislav/dotfiles/pulls') {
+        case params[:head]
+        when "mislav:feature-a"
+          assert :base => "master",
+                 :title => "Add feature A",
+                 :body => ""
+          status 201
+          json :number => 11, :base => { :ref => "master" }
+        when "mislav:feature-b"
+          assert :base => "feature-a",
+                 :title => "Add feature B",
+                 :body => "Depends on #11"
+          status 201
+          json :number => 12, :base => { :ref => "feature-a" }
+        else
+          status 422
+        end
+      }
+      """
+    When I successfully run `hub pr stack`
+    Then the output should contain exactly:
+      """
+      created #11 feature-a -> master
+      created #12 feature-b -> feature-a\n
+      """
+
+  Scenario: Retarget pull requests after a lower one was merged
+    Given the GitHub API server:
+      """
+      get('/repos/mislav/dotfiles/pulls') {
+        assert :state => "all"
+        case params[:head]
+        when "mislav:feature-a"
+          json [
+            { :number => 11, :state => "closed",
+              :merged_at => "2018-04-07T12:34:56Z",
+              :base => { :ref => "master" } },
+          ]
+        when "mislav:feature-b"
+          json [
+            { :number => 12, :state => "open",
+              :base => { :ref => "feature-a" } },
+          ]
+        else
+          json []
+        end
+      }
+      patch('/repos/mislav/dotfiles/pulls/12') {
+        assert :base => "master"
+        json :number => 12, :base => { :ref => "master" }
+      }
+      """
+    When I successfully run `hub pr stack`
+    Then the output should contain exactly:
+      """
+      merged #11 feature-a -> master
+      retargeted #12 feature-b -> master\n
+      """
+
+  Scenario: Stack is already up to date
+    Given the GitHub API server:
+      """
+      get('/repos/mislav/dotfiles/pulls') {
+        case params[:head]
+        when "mislav:feature-a"
+          json [{ :number => 11, :state => "open", :base => { :ref => "master" } }]
+        when "mislav:feature-b"
+          json [{ :number => 12, :state => "open", :base => { :ref => "feature-a" } }]
+        else
+          json []
+        end
+      }
+      """
+    When I successfully run `hub pr stack`
+    Then the output should contain exactly:
+      """
+      unchanged #11 feature-a -> master
+      unchanged #12 feature-b -> feature-a\n
+      """
+
+  Scenario: Branch is not part of a stack
+    Given I successfully run `git checkout --quiet -b unrelated master`
+    When I run `hub pr stack`
+    Then the stderr should contain exactly "Error: branch 'unrelated' is not part of a stack of local branches\n"
+    And the exit status should be 1
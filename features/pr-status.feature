@@ -0,0 +1,150 @@
+Feature: hub pr status
+  Background:
+    Given I am in "git://github.com/friederbluemle/hub.git" git repo
+    And I am "friederbluemle" on github.com with OAuth token "OTOKEN"
+    And I am on the "topic" branch
+
+  Scenario: Show dashboard
+    Given the GitHub API server:
+      """
+      get('/repos/friederbluemle/hub/pulls') {
+        assert :state => "open", :head => "friederbluemle:topic"
+        json [
+          { :number => 12,
+            :title => "Current work",
+            :state => "open",
+            :user => { :login => "friederbluemle" },
+            :head => { :sha => "HEADSHA", :ref => "topic" },
+            :base => { :ref => "main", :repo => { :owner => { :login => "friederbluemle" } } },
+          },
+        ]
+      }
+      post('/graphql') {
+        assert :query => /search\(/
+        case params[:variables][:query]
+        when "repo:friederbluemle/hub is:pr is:open review-requested:@me sort:created-desc"
+          nodes = [
+            { :number => 13,
+              :state => "OPEN",
+              :title => "Please review",
+              :author => { :login => "mislav" },
+              :headRefName => "please-review",
+              :baseRefName => "main",
+              :repository => { :name => "hub", :owner => { :login => "friederbluemle" } },
+            },
+          ]
+        when "repo:friederbluemle/hub is:pr is:open author:@me sort:created-desc"
+          nodes = [
+            { :number => 12,
+              :state => "OPEN",
+              :title => "Current work",
+              :author => { :login => "friederbluemle" },
+              :headRefName => "topic",
+              :baseRefName => "main",
+              :repository => { :name => "hub", :owner => { :login => "friederbluemle" } },
+            },
+          ]
+        else
+          halt 400
+        end
+        json :data => {
+          :search => {
+            :nodes => nodes,
+            :pageInfo => { :hasNextPage => false, :endCursor => "CURSOR" },
+          }
+        }
+      }
+      get('/repos/friederbluemle/hub/commits/HEADSHA/status') {
+        json :state => "success",
+             :statuses => [
+               { :state => "success", :context => "continuous-integration/travis-ci/push" },
+             ]
+      }
+      get('/repos/friederbluemle/hub/commits/HEADSHA/check-runs') {
+        json :check_runs => []
+      }
+      """
+    When I successfully run `hub pr status`
+    Then the output should contain exactly:
+      """
+      Current branch:
+        #12  Current work  success
+
+      Requesting a code review from you:
+        #13  Please review
+
+      Created by you:
+        #12  Current work\n
+      """
+
+  Scenario: Format the pull request for the current branch
+    Given the GitHub API server:
+      """
+      get('/repos/friederbluemle/hub/pulls') {
+        assert :state => "open", :head => "friederbluemle:topic"
+        json [
+          { :number => 12,
+            :title => "Current work",
+            :state => "open",
+            :user => { :login => "friederbluemle" },
+            :head => { :sha => "HEADSHA", :ref => "topic" },
+            :base => { :ref => "main", :repo => { :owner => { :login => "friederbluemle" } } },
+          },
+        ]
+      }
+      get('/repos/friederbluemle/hub/commits/HEADSHA/status') {
+        json :state => "pending",
+             :statuses => [
+               { :state => "pending", :context => "continuous-integration/travis-ci/push" },
+             ]
+      }
+      get('/repos/friederbluemle/hub/commits/HEADSHA/check-runs') {
+        json :check_runs => []
+      }
+      """
+    When I successfully run `hub pr status -f "%i:%cS"`
+    Then the output should contain exactly "#12:pending"
+
+  Scenario: No pull requests
+    Given the GitHub API server:
+      """
+      get('/repos/friederbluemle/hub/pulls') {
+        json []
+      }
+      post('/graphql') {
+        json :data => {
+          :search => {
+            :nodes => [],
+            :pageInfo => { :hasNextPage => false, :endCursor => nil },
+          }
+        }
+      }
+      """
+    When I successfully run `hub pr status`
+    Then the output should contain exactly:
+      """
+      Current branch:
+        There is no pull request associated with [topic]
+
+      Requesting a code review from you:
+        You have no pull requests to review
+
+      Created by you:
+        You have no open pull requests\n
+      """
+
+  Scenario: Failure to look up the pull request for the current branch
+    Given the GitHub API server:
+      """
+      get('/repos/friederbluemle/hub/pulls') {
+        status 500
+        json :message => "Server Error"
+      }
+      post('/graphql') {
+        halt 400, "should not search"
+      }
+      """
+    When I run `hub pr status`
+    Then the exit status should be 1
+    And the stderr should contain "Error fetching pull requests"
+    And the output should not contain "There is no pull request"
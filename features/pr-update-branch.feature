@@ -0,0 +1,123 @@
+Feature: hub pr update-branch
+  Background:
+    Given I am in "git://github.com/friederbluemle/hub.git" git repo
+    And I am "friederbluemle" on github.com with OAuth token "OTOKEN"
+
+  Scenario: Merge the base branch into the head branch
+    Given the GitHub API server:
+      """
+      get('/repos/friederbluemle/hub/pulls/12') {
+        json :number => 12,
+          :head => { :ref => "feature", :sha => "HEADSHA" },
+          :base => { :ref => "master" }
+      }
+      put('/repos/friederbluemle/hub/pulls/12/update-branch') {
+        assert :expected_head_sha => "HEADSHA"
+        status 202
+        json :message => "Updating pull request branch."
+      }
+      """
+    When I successfully run `hub pr update-branch 12`
+    Then the output should contain exactly "Merging master into feature for pull request #12\n"
+
+  Scenario: Head branch has changed in the meantime
+    Given the GitHub API server:
+      """
+      get('/repos/friederbluemle/hub/pulls/12') {
+        json :number => 12,
+          :head => { :ref => "feature", :sha => "HEADSHA" },
+          :base => { :ref => "master" }
+      }
+      put('/repos/friederbluemle/hub/pulls/12/update-branch') {
+        status 422
+        json :message => "expected head sha didn’t match current head ref."
+      }
+      """
+    When I run `hub pr update-branch 12`
+    Then the exit status should be 1
+    And the stderr should contain "Error updating pull request branch: Unprocessable Entity (HTTP 422)"
+
+  Scenario: Rebase and fast-forward the current branch
+    Given I am on the "feature" branch with upstream "origin/feature"
+    Given the GitHub API server:
+      """
+      head_sha = cd('.') { `git rev-parse HEAD`.chomp }
+      get('/repos/friederbluemle/hub/pulls') {
+        assert :head => "friederbluemle:feature", :state => "open"
+        json [
+          { :number => 12, :node_id => "PR_NODE",
+            :head => { :ref => "feature", :sha => head_sha,
+                       :repo => { :name => "hub", :owner => { :login => "friederbluemle" } } },
+            :base => { :ref => "master" } },
+        ]
+      }
+      post('/graphql') {
+        assert :query => /updatePullRequestBranch\(.+updateMethod: REBASE/,
+          :variables => { :id => "PR_NODE", :sha => head_sha }
+        json :data => {
+          :updatePullRequestBranch => { :pullRequest => { :headRefOid => "NEWSHA" } }
+        }
+      }
+      """
+    When I successfully run `hub pr update-branch --rebase --fast-forward`
+    Then the output should contain exactly "Rebased feature onto master for pull request #12\n"
+    And "git fetch origin feature" should be run
+    And "git reset --keep refs/remotes/origin/feature" should be run
+
+  Scenario: Rebase does not move a local branch with other commits
+    Given I am on the "feature" branch with upstream "origin/feature"
+    Given the GitHub API server:
+      """
+      get('/repos/friederbluemle/hub/pulls') {
+        json [
+          { :number => 12, :node_id => "PR_NODE",
+            :head => { :ref => "feature", :sha => "HEADSHA",
+                       :repo => { :name => "hub", :owner => { :login => "friederbluemle" } } },
+            :base => { :ref => "master" } },
+        ]
+      }
+      post('/graphql') {
+        json :data => {
+          :updatePullRequestBranch => { :pullRequest => { :headRefOid => "NEWSHA" } }
+        }
+      }
+      """
+    When I successfully run `hub pr update-branch --rebase --fast-forward`
+    Then the stderr should contain exactly "Skipping fast-forward: the local feature branch does not match the head of pull request #12 before the rebase\n"
+    And "git reset --keep refs/remotes/origin/feature" should not be run
+
+  Scenario: Fast-forward is skipped for a branch of the same name from a fork
+    Given I am on the "feature" branch with upstream "origin/feature"
+    Given the GitHub API server:
+      """
+      get('/repos/friederbluemle/hub/pulls/12') {
+        json :number => 12,
+          :head => { :ref => "feature", :sha => "HEADSHA",
+                     :repo => { :name => "hub", :owner => { :login => "mislav" } } },
+          :base => { :ref => "master" }
+      }
+      put('/repos/friederbluemle/hub/pulls/12/update-branch') {
+        status 202
+        json :message => "Updating pull request branch."
+      }
+      """
+    When I successfully run `hub pr update-branch --fast-forward 12`
+    Then the stderr should contain exactly "Skipping fast-forward: feature is not checked out\n"
+    And "git merge --ff-only refs/remotes/origin/feature" should not be run
+
+  Scenario: Fast-forward is skipped when the branch is not checked out
+    Given the GitHub API server:
+      """
+      get('/repos/friederbluemle/hub/pulls/12') {
+        json :number => 12, :node_id => "PR_NODE",
+          :head => { :ref => "feature", :sha => "HEADSHA" },
+          :base => { :ref => "master" }
+      }
+      post('/graphql') {
+        json :data => {
+          :updatePullRequestBranch => { :pullRequest => { :headRefOid => "NEWSHA" } }
+        }
+      }
+      """
+    When I successfully run `hub pr update-branch --rebase --fast-forward 12`
+    Then the stderr should contain exactly "Skipping fast-forward: feature is not checked out\n"
@@ -202,6 +202,67 @@ Feature: hub pull-request
     When I successfully run `hub pull-request`
     Then the output should contain exactly "the://url\n"
 
+  Scenario: Single-commit with a named pull request template
+    Given the git commit editor is "true"
+    Given the GitHub API server:
+      """
+      post('/repos/mislav/coral/pulls') {
+        assert :title => 'Commit title',
+               :body => "Commit body\n\n\nFixes #"
+        status 201
+        json :html_url => "the://url"
+      }
+      """
+    Given I am on the "master" branch pushed to "origin/master"
+    When I successfully run `git checkout --quiet -b topic`
+    And I make a commit with message:
+      """
+      Commit title
+
+      Commit body
+      """
+    And the "topic" branch is pushed to "origin/topic"
+    And a file named ".github/PULL_REQUEST_TEMPLATE/bugfix.md" with:
+      """
+      Fixes #
+      """
+    And a file named ".github/PULL_REQUEST_TEMPLATE/feature.md" with:
+      """
+      Adds a feature
+      """
+    When I successfully run `hub pull-request --template bugfix`
+    Then the output should contain exactly "the://url\n"
+
+  Scenario: Default pull request template with "--no-edit" and named templates
+    Given the GitHub API server:
+      """
+      post('/repos/mislav/coral/pulls') {
+        assert :title => 'Commit title',
+               :body => "Commit body\n\n\nDefault template"
+        status 201
+        json :html_url => "the://url"
+      }
+      """
+    Given I am on the "master" branch pushed to "origin/master"
+    When I successfully run `git checkout --quiet -b topic`
+    And I make a commit with message:
+      """
+      Commit title
+
+      Commit body
+      """
+    And the "topic" branch is pushed to "origin/topic"
+    And a file named ".github/PULL_REQUEST_TEMPLATE.md" with:
+      """
+      Default template
+      """
+    And a file named ".github/PULL_REQUEST_TEMPLATE/bugfix.md" with:
+      """
+      Fixes #
+      """
+    When I successfully run `hub pull-request --fill --no-edit`
+    Then the output should contain exactly "the://url\n"
+
   Scenario: Single-commit pull request with "--no-edit"
     Given the GitHub API server:
       """
@@ -1385,3 +1446,120 @@ Feature: hub pull-request
       """
     When I successfully run `hub pull-request -m hello --no-maintainer-edits`
     Then the output should contain exactly "the://url\n"
+
+  Scenario: Update an existing pull request
+    Given I am on the "feature" branch with upstream "origin/feature"
+    Given the GitHub API server:
+      """
+      post('/repos/mislav/coral/pulls') {
+        status 422
+        json :message => "Validation Failed",
+          :errors => [{
+            :resource => "PullRequest",
+            :code => "custom",
+            :message => "A pull request already exists for mislav:feature.",
+          }]
+      }
+      get('/repos/mislav/coral/pulls') {
+        assert :head => "mislav:feature",
+               :state => "open"
+        json [{ :number => 1234 }]
+      }
+      patch('/repos/mislav/coral/pulls/1234') {
+        assert :title => "hereyougo",
+               :body => "updated description"
+        json :html_url => "the://url", :number => 1234,
+          :requested_reviewers => [{ :login => "josh" }]
+      }
+      patch('/repos/mislav/coral/issues/1234') {
+        assert :labels => ["feature"]
+        json :html_url => "the://url"
+      }
+      post('/repos/mislav/coral/pulls/1234/requested_reviewers') {
+        assert :reviewers => ["pcorpet"]
+        status 201
+        json :html_url => "the://url"
+      }
+      """
+    When I successfully run `hub pull-request --update -m hereyougo -m "updated description" -l feature -r josh,pcorpet`
+    Then the output should contain exactly "the://url\n"
+
+  Scenario: Pull request already exists
+    Given I am on the "feature" branch with upstream "origin/feature"
+    Given the GitHub API server:
+      """
+      post('/repos/mislav/coral/pulls') {
+        status 422
+        json :message => "Validation Failed",
+          :errors => [{
+            :resource => "PullRequest",
+            :code => "custom",
+            :message => "A pull request already exists for mislav:feature.",
+          }]
+      }
+      """
+    When I run `hub pull-request -m hereyougo`
+    Then the stderr should contain "A pull request already exists for mislav:feature."
+    And the exit status should be 1
+
+  Scenario: Fill title and body from commit log
+    Given the GitHub API server:
+      """
+      post('/repos/mislav/coral/pulls') {
+        assert :title => 'Login form',
+               :body => "### Features\n\n- **ui:** add login button\n\n### Bug Fixes\n\n- handle empty name\n\n### Other Changes\n\n- Update readme\n\nFixes #12"
+        status 201
+        json :html_url => "the://url"
+      }
+      """
+    Given I am on the "master" branch pushed to "origin/master"
+    When I successfully run `git checkout --quiet -b feature/login-form`
+    Given I make a commit with message:
+      """
+      feat(ui): add login button
+
+      Fixes #12
+      """
+    And I make a commit with message "fix: handle empty name"
+    And I make a commit with message "Update readme"
+    And the "feature/login-form" branch is pushed to "origin/feature/login-form"
+    When I successfully run `hub pull-request --fill=conventional --no-edit`
+    Then the output should contain exactly "the://url\n"
+
+  Scenario: Fill body format from git config
+    Given the GitHub API server:
+      """
+      post('/repos/mislav/coral/pulls') {
+        assert :title => 'Topic',
+               :body => "- One on topic\n- Two on topic"
+        status 201
+        json :html_url => "the://url"
+      }
+      """
+    Given I am on the "master" branch pushed to "origin/master"
+    When I successfully run `git checkout --quiet -b topic`
+    Given I make a commit with message "One on topic"
+    And I make a commit with message "Two on topic"
+    And the "topic" branch is pushed to "origin/topic"
+    And I successfully run `git config hub.pullRequestBodyFormat subjects`
+    When I successfully run `hub pull-request --no-edit --fill`
+    Then the output should contain exactly "the://url\n"
+
+  Scenario: Fill body format from git config without the --fill flag
+    Given the GitHub API server:
+      """
+      post('/repos/mislav/coral/pulls') {
+        assert :title => 'Topic',
+               :body => "- One on topic\n- Two on topic"
+        status 201
+        json :html_url => "the://url"
+      }
+      """
+    Given I am on the "master" branch pushed to "origin/master"
+    When I successfully run `git checkout --quiet -b topic`
+    Given I make a commit with message "One on topic"
+    And I make a commit with message "Two on topic"
+    And the "topic" branch is pushed to "origin/topic"
+    And I successfully run `git config hub.pullRequestBodyFormat subjects`
+    When I successfully run `hub pull-request --no-edit`
+    Then the output should contain exactly "the://url\n"
@@ -115,6 +115,17 @@ func Editor() (string, error) {
 	return os.ExpandEnv(firstLine(output)), nil
 }
 
+func Pager() (string, error) {
+	varCmd := gitCmd("var", "GIT_PAGER")
+	varCmd.Stderr = nil
+	output, err := varCmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("Can't load git var: GIT_PAGER")
+	}
+
+	return os.ExpandEnv(firstLine(output)), nil
+}
+
 func Head() (string, error) {
 	return SymbolicRef("HEAD")
 }
@@ -239,6 +250,13 @@ func Log(sha1, sha2 string) (string, error) {
 	return outputs, nil
 }
 
+// Cherry lists the commits in limit..head, each prefixed with "-" when an
+// equivalent change exists in upstream and with "+" otherwise
+func Cherry(upstream, head, limit string) ([]string, error) {
+	output, err := gitCmd("cherry", upstream, head, limit).Output()
+	return outputLines(output), err
+}
+
 func Remotes() ([]string, error) {
 	remoteCmd := gitCmd("remote", "-v")
 	remoteCmd.Stderr = nil
@@ -333,6 +351,36 @@ func LocalBranches() ([]string, error) {
 	return branches, nil
 }
 
+// Worktree describes a working tree attached to the repository
+type Worktree struct {
+	Path   string
+	Head   string
+	Branch string
+}
+
+// Worktrees lists all working trees of the repository, starting with the main one
+func Worktrees() ([]Worktree, error) {
+	listCmd := gitCmd("worktree", "list", "--porcelain")
+	output, err := listCmd.Output()
+	if err != nil {
+		return nil, err
+	}
+
+	worktrees := []Worktree{}
+	for _, line := range outputLines(output) {
+		parts := strings.SplitN(line, " ", 2)
+		switch parts[0] {
+		case "worktree":
+			worktrees = append(worktrees, Worktree{Path: parts[1]})
+		case "HEAD":
+			worktrees[len(worktrees)-1].Head = parts[1]
+		case "branch":
+			worktrees[len(worktrees)-1].Branch = parts[1]
+		}
+	}
+	return worktrees, nil
+}
+
 func outputLines(output string) []string {
 	output = strings.TrimSuffix(output, "\n")
 	if output == "" {
@@ -3,6 +3,7 @@ package git
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -42,6 +43,50 @@ func TestGitEditor(t *testing.T) {
 	assert.Equal(t, `hello "happy world"`, gitEditor)
 }
 
+func TestGitPager(t *testing.T) {
+	repo := fixtures.SetupTestRepo()
+	pager := os.Getenv("GIT_PAGER")
+	if err := os.Unsetenv("GIT_PAGER"); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		repo.TearDown()
+		if err := os.Setenv("GIT_PAGER", pager); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	SetGlobalConfig("core.pager", "less -S")
+	gitPager, err := Pager()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "less -S", gitPager)
+
+	os.Setenv("GIT_PAGER", "cat")
+	gitPager, err = Pager()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "cat", gitPager)
+}
+
+func TestWorktrees(t *testing.T) {
+	repo := fixtures.SetupTestRepo()
+	defer repo.TearDown()
+
+	worktreePath := filepath.Join(os.Getenv("HOME"), "test-worktree")
+	err := Run("worktree", "add", "--quiet", "-b", "topic", worktreePath, "HEAD")
+	assert.Equal(t, nil, err)
+
+	worktrees, err := Worktrees()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 2, len(worktrees))
+	assert.Equal(t, "refs/heads/master", worktrees[0].Branch)
+
+	expectedPath, _ := filepath.EvalSymlinks(worktreePath)
+	actualPath, _ := filepath.EvalSymlinks(worktrees[1].Path)
+	assert.Equal(t, expectedPath, actualPath)
+	assert.Equal(t, "refs/heads/topic", worktrees[1].Branch)
+	assert.Equal(t, 40, len(worktrees[1].Head))
+}
+
 func TestGitLog(t *testing.T) {
 	repo := fixtures.SetupTestRepo()
 	defer repo.TearDown()
@@ -15,6 +15,7 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/github/hub/v2/version"
@@ -38,6 +39,8 @@ func NewClientWithHost(host *Host) *Client {
 type Client struct {
 	Host         *Host
 	cachedClient *simpleClient
+	// guards Host and cachedClient when making requests concurrently
+	mu sync.Mutex
 }
 
 type Gist struct {
@@ -94,6 +97,191 @@ func (client *Client) FetchPullRequests(project *Project, filterParams map[strin
 	return
 }
 
+// SearchPullRequests finds pull requests in a repository that match a search
+// query using the GraphQL search API
+func (client *Client) SearchPullRequests(project *Project, query string, limit int, filter func(*PullRequest) bool) (pulls []PullRequest, err error) {
+	graphQuery := `
+	query($query: String!, $perPage: Int!, $endCursor: String) {
+		search(query: $query, type: ISSUE, first: $perPage, after: $endCursor) {
+			nodes {
+				...on PullRequest {
+					number
+					id
+					state
+					title
+					body
+					url
+					isDraft
+					maintainerCanModify
+					author { login }
+					comments { totalCount }
+					labels(first: 100) { nodes { name color } }
+					assignees(first: 100) { nodes { login } }
+					milestone { number title }
+					reviewRequests(first: 100) {
+						nodes {
+							requestedReviewer {
+								...on User { login }
+								...on Team { name slug }
+							}
+						}
+					}
+					createdAt
+					updatedAt
+					mergedAt
+					mergeCommit { oid }
+					headRefName
+					headRefOid
+					headRepository { name owner { login } }
+					baseRefName
+					baseRefOid
+					repository { name owner { login } }
+				}
+			}
+			pageInfo {
+				hasNextPage
+				endCursor
+			}
+		}
+	}`
+
+	type repository struct {
+		Name  string
+		Owner User
+	}
+	type searchResult struct {
+		Search struct {
+			Nodes []struct {
+				Number              int
+				ID                  string
+				State               string
+				Title               string
+				Body                string
+				URL                 string
+				IsDraft             bool
+				MaintainerCanModify bool
+				Author              *User
+				Comments            struct {
+					TotalCount int
+				}
+				Labels struct {
+					Nodes []IssueLabel
+				}
+				Assignees struct {
+					Nodes []User
+				}
+				Milestone      *Milestone
+				ReviewRequests struct {
+					Nodes []struct {
+						RequestedReviewer struct {
+							Login string
+							Name  string
+							Slug  string
+						}
+					}
+				}
+				CreatedAt   time.Time
+				UpdatedAt   time.Time
+				MergedAt    time.Time
+				MergeCommit *struct {
+					Oid string
+				}
+				HeadRefName    string
+				HeadRefOid     string
+				HeadRepository *repository
+				BaseRefName    string
+				BaseRefOid     string
+				Repository     repository
+			}
+			PageInfo struct {
+				HasNextPage bool
+				EndCursor   string
+			}
+		}
+	}
+
+	pulls = []PullRequest{}
+	variables := map[string]interface{}{
+		"query":   query,
+		"perPage": perPage(limit, 100),
+	}
+
+	for {
+		result := searchResult{}
+		if err = client.GraphQL(graphQuery, variables, &result); err != nil {
+			return
+		}
+
+		for _, node := range result.Search.Nodes {
+			if node.Number == 0 {
+				continue
+			}
+			pr := PullRequest{
+				Number:              node.Number,
+				NodeID:              node.ID,
+				State:               strings.ToLower(node.State),
+				Title:               node.Title,
+				Body:                node.Body,
+				User:                node.Author,
+				Draft:               node.IsDraft,
+				MaintainerCanModify: node.MaintainerCanModify,
+				Comments:            node.Comments.TotalCount,
+				Labels:              node.Labels.Nodes,
+				Assignees:           node.Assignees.Nodes,
+				Milestone:           node.Milestone,
+				CreatedAt:           node.CreatedAt,
+				UpdatedAt:           node.UpdatedAt,
+				MergedAt:            node.MergedAt,
+				HTMLURL:             node.URL,
+			}
+			if pr.State == "merged" {
+				pr.State = "closed"
+			}
+			if node.MergeCommit != nil {
+				pr.MergeCommitSha = node.MergeCommit.Oid
+			}
+			for _, request := range node.ReviewRequests.Nodes {
+				reviewer := request.RequestedReviewer
+				if reviewer.Slug != "" {
+					pr.RequestedTeams = append(pr.RequestedTeams, Team{Name: reviewer.Name, Slug: reviewer.Slug})
+				} else if reviewer.Login != "" {
+					pr.RequestedReviewers = append(pr.RequestedReviewers, User{Login: reviewer.Login})
+				}
+			}
+
+			baseRepo := node.Repository
+			pr.Base = &PullRequestSpec{
+				Label: fmt.Sprintf("%s:%s", baseRepo.Owner.Login, node.BaseRefName),
+				Ref:   node.BaseRefName,
+				Sha:   node.BaseRefOid,
+				Repo:  &Repository{Name: baseRepo.Name, Owner: &User{Login: baseRepo.Owner.Login}},
+			}
+			pr.Head = &PullRequestSpec{
+				Ref: node.HeadRefName,
+				Sha: node.HeadRefOid,
+			}
+			if headRepo := node.HeadRepository; headRepo != nil {
+				pr.Head.Label = fmt.Sprintf("%s:%s", headRepo.Owner.Login, node.HeadRefName)
+				pr.Head.Repo = &Repository{Name: headRepo.Name, Owner: &User{Login: headRepo.Owner.Login}}
+			}
+
+			if filter == nil || filter(&pr) {
+				pulls = append(pulls, pr)
+				if limit > 0 && len(pulls) == limit {
+					return
+				}
+			}
+		}
+
+		if !result.Search.PageInfo.HasNextPage {
+			break
+		}
+		variables["endCursor"] = result.Search.PageInfo.EndCursor
+	}
+
+	return
+}
+
 func (client *Client) PullRequest(project *Project, id string) (pr *PullRequest, err error) {
 	api, err := client.simpleAPI()
 	if err != nil {
@@ -111,6 +299,290 @@ func (client *Client) PullRequest(project *Project, id string) (pr *PullRequest,
 	return
 }
 
+type PullRequestReviewData struct {
+	// the latest approving or changes-requesting review of each reviewer
+	Reviews []PullRequestReview
+	Threads []PullRequestReviewThread
+}
+
+type PullRequestReviewThread struct {
+	Path       string
+	Line       int
+	IsResolved bool
+	IsOutdated bool
+	// the diff context that the first comment of the thread was made on
+	DiffHunk string
+	Comments []Comment
+}
+
+func (data *PullRequestReviewData) UnresolvedThreads() int {
+	count := 0
+	for _, thread := range data.Threads {
+		if !thread.IsResolved {
+			count++
+		}
+	}
+	return count
+}
+
+func (client *Client) PullRequestReviewData(project *Project, prNumber int) (data *PullRequestReviewData, err error) {
+	query := `
+	query($owner: String!, $name: String!, $number: Int!, $endCursor: String) {
+		repository(owner: $owner, name: $name) {
+			pullRequest(number: $number) {
+				latestOpinionatedReviews(first: 100) {
+					nodes {
+						state
+						author { login }
+						submittedAt
+					}
+				}
+				reviewThreads(first: 100, after: $endCursor) {
+					nodes {
+						path
+						line
+						originalLine
+						isResolved
+						isOutdated
+						comments(first: 100) {
+							nodes {
+								databaseId
+								body
+								diffHunk
+								url
+								createdAt
+								author { login }
+							}
+						}
+					}
+					pageInfo {
+						hasNextPage
+						endCursor
+					}
+				}
+			}
+		}
+	}`
+
+	type reviewDataResult struct {
+		Repository struct {
+			PullRequest struct {
+				LatestOpinionatedReviews struct {
+					Nodes []struct {
+						State       string
+						Author      *User
+						SubmittedAt time.Time
+					}
+				}
+				ReviewThreads struct {
+					Nodes []struct {
+						Path         string
+						Line         int
+						OriginalLine int
+						IsResolved   bool
+						IsOutdated   bool
+						Comments     struct {
+							Nodes []struct {
+								DatabaseID int
+								Body       string
+								DiffHunk   string
+								URL        string
+								CreatedAt  time.Time
+								Author     *User
+							}
+						}
+					}
+					PageInfo struct {
+						HasNextPage bool
+						EndCursor   string
+					}
+				}
+			}
+		}
+	}
+
+	variables := map[string]interface{}{
+		"owner":  project.Owner,
+		"name":   project.Name,
+		"number": prNumber,
+	}
+
+	data = &PullRequestReviewData{
+		Reviews: []PullRequestReview{},
+		Threads: []PullRequestReviewThread{},
+	}
+	for {
+		result := reviewDataResult{}
+		if err = client.GraphQL(query, variables, &result); err != nil {
+			return
+		}
+
+		pullRequest := result.Repository.PullRequest
+		if variables["endCursor"] == nil {
+			for _, node := range pullRequest.LatestOpinionatedReviews.Nodes {
+				data.Reviews = append(data.Reviews, PullRequestReview{
+					State:       node.State,
+					User:        node.Author,
+					SubmittedAt: node.SubmittedAt,
+				})
+			}
+		}
+
+		for _, node := range pullRequest.ReviewThreads.Nodes {
+			thread := PullRequestReviewThread{
+				Path:       node.Path,
+				Line:       node.Line,
+				IsResolved: node.IsResolved,
+				IsOutdated: node.IsOutdated,
+				Comments:   []Comment{},
+			}
+			if thread.Line == 0 {
+				thread.Line = node.OriginalLine
+			}
+			for _, comment := range node.Comments.Nodes {
+				if thread.DiffHunk == "" {
+					thread.DiffHunk = comment.DiffHunk
+				}
+				thread.Comments = append(thread.Comments, Comment{
+					ID:        comment.DatabaseID,
+					Body:      comment.Body,
+					User:      comment.Author,
+					CreatedAt: comment.CreatedAt,
+					HTMLURL:   comment.URL,
+				})
+			}
+			data.Threads = append(data.Threads, thread)
+		}
+
+		pageInfo := pullRequest.ReviewThreads.PageInfo
+		if !pageInfo.HasNextPage {
+			break
+		}
+		variables["endCursor"] = pageInfo.EndCursor
+	}
+
+	return
+}
+
+// how many pull requests to look up review data for in a single GraphQL query
+const reviewDataBatchSize = 50
+
+// PullRequestsReviewData fetches the reviews and review thread states of
+// several pull requests at once. Unlike PullRequestReviewData, it skips the
+// contents of review comments.
+func (client *Client) PullRequestsReviewData(project *Project, prNumbers []int) (data map[int]*PullRequestReviewData, err error) {
+	type reviewDataResult struct {
+		LatestOpinionatedReviews struct {
+			Nodes []struct {
+				State       string
+				Author      *User
+				SubmittedAt time.Time
+			}
+		}
+		ReviewThreads struct {
+			Nodes []struct {
+				Path         string
+				Line         int
+				OriginalLine int
+				IsResolved   bool
+				IsOutdated   bool
+			}
+			PageInfo struct {
+				HasNextPage bool
+			}
+		}
+	}
+
+	variables := map[string]interface{}{
+		"owner": project.Owner,
+		"name":  project.Name,
+	}
+
+	data = map[int]*PullRequestReviewData{}
+	for start := 0; start < len(prNumbers); start += reviewDataBatchSize {
+		end := start + reviewDataBatchSize
+		if end > len(prNumbers) {
+			end = len(prNumbers)
+		}
+
+		var query strings.Builder
+		query.WriteString("query($owner: String!, $name: String!) {\n\trepository(owner: $owner, name: $name) {\n")
+		for _, number := range prNumbers[start:end] {
+			fmt.Fprintf(&query, `		pr%d: pullRequest(number: %d) {
+			latestOpinionatedReviews(first: 100) {
+				nodes {
+					state
+					author { login }
+					submittedAt
+				}
+			}
+			reviewThreads(first: 100) {
+				nodes {
+					path
+					line
+					originalLine
+					isResolved
+					isOutdated
+				}
+				pageInfo {
+					hasNextPage
+				}
+			}
+		}
+`, number, number)
+		}
+		query.WriteString("\t}\n}")
+
+		result := struct {
+			Repository map[string]*reviewDataResult
+		}{}
+		if err = client.GraphQL(query.String(), variables, &result); err != nil {
+			return
+		}
+
+		for _, number := range prNumbers[start:end] {
+			pullRequest := result.Repository[fmt.Sprintf("pr%d", number)]
+			if pullRequest == nil {
+				continue
+			}
+			if pullRequest.ReviewThreads.PageInfo.HasNextPage {
+				if data[number], err = client.PullRequestReviewData(project, number); err != nil {
+					return
+				}
+				continue
+			}
+
+			prData := &PullRequestReviewData{
+				Reviews: []PullRequestReview{},
+				Threads: []PullRequestReviewThread{},
+			}
+			for _, node := range pullRequest.LatestOpinionatedReviews.Nodes {
+				prData.Reviews = append(prData.Reviews, PullRequestReview{
+					State:       node.State,
+					User:        node.Author,
+					SubmittedAt: node.SubmittedAt,
+				})
+			}
+			for _, node := range pullRequest.ReviewThreads.Nodes {
+				thread := PullRequestReviewThread{
+					Path:       node.Path,
+					Line:       node.Line,
+					IsResolved: node.IsResolved,
+					IsOutdated: node.IsOutdated,
+					Comments:   []Comment{},
+				}
+				if thread.Line == 0 {
+					thread.Line = node.OriginalLine
+				}
+				prData.Threads = append(prData.Threads, thread)
+			}
+			data[number] = prData
+		}
+	}
+
+	return
+}
+
 func (client *Client) PullRequestPatch(project *Project, id string) (patch io.ReadCloser, err error) {
 	api, err := client.simpleAPI()
 	if err != nil {
@@ -125,6 +597,20 @@ func (client *Client) PullRequestPatch(project *Project, id string) (patch io.Re
 	return res.Body, nil
 }
 
+func (client *Client) PullRequestDiff(project *Project, id string) (diff io.ReadCloser, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	res, err := api.GetFile(fmt.Sprintf("repos/%s/%s/pulls/%s", project.Owner, project.Name, id), diffMediaType)
+	if err = checkStatus(200, "getting pull request diff", res, err); err != nil {
+		return
+	}
+
+	return res.Body, nil
+}
+
 func (client *Client) CreatePullRequest(project *Project, params map[string]interface{}) (pr *PullRequest, err error) {
 	api, err := client.simpleAPI()
 	if err != nil {
@@ -146,6 +632,22 @@ func (client *Client) CreatePullRequest(project *Project, params map[string]inte
 	return
 }
 
+func (client *Client) UpdatePullRequest(project *Project, prNumber int, params map[string]interface{}) (pr *PullRequest, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	res, err := api.PatchJSON(fmt.Sprintf("repos/%s/%s/pulls/%d", project.Owner, project.Name, prNumber), params)
+	if err = checkStatus(200, "updating pull request", res, err); err != nil {
+		return
+	}
+
+	pr = &PullRequest{}
+	err = res.Unmarshal(pr)
+	return
+}
+
 type PullRequestMergeResponse struct {
 	SHA     string
 	Merged  bool
@@ -168,6 +670,24 @@ func (client *Client) MergePullRequest(project *Project, prNumber int, params ma
 	return
 }
 
+func (client *Client) UpdatePullRequestBranch(project *Project, prNumber int, expectedHeadSha string) (err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	params := map[string]interface{}{
+		"expected_head_sha": expectedHeadSha,
+	}
+	res, err := api.PutJSON(fmt.Sprintf("repos/%s/%s/pulls/%d/update-branch", project.Owner, project.Name, prNumber), params)
+	if err = checkStatus(202, "updating pull request branch", res, err); err != nil {
+		return
+	}
+
+	res.Body.Close()
+	return
+}
+
 func (client *Client) DeleteBranch(project *Project, branchName string) (err error) {
 	api, err := client.simpleAPI()
 	if err != nil {
@@ -203,6 +723,135 @@ func (client *Client) RequestReview(project *Project, prNumber int, params map[s
 	return
 }
 
+type PullRequestReview struct {
+	ID          int       `json:"id"`
+	State       string    `json:"state"`
+	Body        string    `json:"body"`
+	User        *User     `json:"user"`
+	CommitID    string    `json:"commit_id"`
+	HTMLURL     string    `json:"html_url"`
+	SubmittedAt time.Time `json:"submitted_at"`
+}
+
+type PullRequestReviewComment struct {
+	Path string `json:"path"`
+	Line int    `json:"line"`
+	Side string `json:"side,omitempty"`
+	Body string `json:"body"`
+}
+
+func (client *Client) CreatePullRequestReview(project *Project, prNumber int, params map[string]interface{}) (review *PullRequestReview, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	res, err := api.PostJSON(fmt.Sprintf("repos/%s/%s/pulls/%d/reviews", project.Owner, project.Name, prNumber), params)
+	if err = checkStatus(200, "submitting review", res, err); err != nil {
+		return
+	}
+
+	review = &PullRequestReview{}
+	err = res.Unmarshal(review)
+	return
+}
+
+func (client *Client) FetchPullRequestReviews(project *Project, prNumber int) (reviews []PullRequestReview, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	path := fmt.Sprintf("repos/%s/%s/pulls/%d/reviews?per_page=100", project.Owner, project.Name, prNumber)
+
+	reviews = []PullRequestReview{}
+	var res *simpleResponse
+
+	for path != "" {
+		res, err = api.Get(path)
+		if err = checkStatus(200, "fetching reviews", res, err); err != nil {
+			return
+		}
+		path = res.Link("next")
+
+		reviewsPage := []PullRequestReview{}
+		if err = res.Unmarshal(&reviewsPage); err != nil {
+			return
+		}
+		reviews = append(reviews, reviewsPage...)
+	}
+
+	return
+}
+
+type BranchProtection struct {
+	RequiredStatusChecks       *RequiredStatusChecks       `json:"required_status_checks"`
+	RequiredPullRequestReviews *RequiredPullRequestReviews `json:"required_pull_request_reviews"`
+}
+
+type RequiredStatusChecks struct {
+	Strict   bool                  `json:"strict"`
+	Contexts []string              `json:"contexts"`
+	Checks   []RequiredStatusCheck `json:"checks"`
+}
+
+type RequiredStatusCheck struct {
+	Context string `json:"context"`
+}
+
+// RequiredContexts lists the names of required checks from both the legacy
+// "contexts" list and the newer "checks" list
+func (r *RequiredStatusChecks) RequiredContexts() []string {
+	contexts := []string{}
+	seen := map[string]bool{}
+	add := func(context string) {
+		if !seen[context] {
+			seen[context] = true
+			contexts = append(contexts, context)
+		}
+	}
+	for _, context := range r.Contexts {
+		add(context)
+	}
+	for _, check := range r.Checks {
+		add(check.Context)
+	}
+	return contexts
+}
+
+type RequiredPullRequestReviews struct {
+	RequiredApprovingReviewCount int `json:"required_approving_review_count"`
+}
+
+// ErrBranchProtectionUnavailable means that the protection rules of a branch
+// could not be read, which usually requires admin access to the repository
+var ErrBranchProtectionUnavailable = errors.New("branch protection rules are not available")
+
+// FetchBranchProtection returns the protection rules of a branch, or nil if
+// the branch is not protected
+func (client *Client) FetchBranchProtection(project *Project, branch string) (protection *BranchProtection, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	res, err := api.Get(fmt.Sprintf("repos/%s/%s/branches/%s/protection", project.Owner, project.Name, url.PathEscape(branch)))
+	if err == nil && (res.StatusCode == 403 || res.StatusCode == 404) {
+		if errInfo, infoErr := res.ErrorInfo(); res.StatusCode == 404 && infoErr == nil && errInfo.Message == "Branch not protected" {
+			return
+		}
+		err = ErrBranchProtectionUnavailable
+		return
+	}
+	if err = checkStatus(200, "fetching branch protection", res, err); err != nil {
+		return
+	}
+
+	protection = &BranchProtection{}
+	err = res.Unmarshal(protection)
+	return
+}
+
 func (client *Client) CommitPatch(project *Project, sha string) (patch io.ReadCloser, err error) {
 	api, err := client.simpleAPI()
 	if err != nil {
@@ -636,10 +1285,12 @@ type Comment struct {
 	Body      string    `json:"body"`
 	User      *User     `json:"user"`
 	CreatedAt time.Time `json:"created_at"`
+	HTMLURL   string    `json:"html_url"`
 }
 
 type Issue struct {
 	Number int    `json:"number"`
+	NodeID string `json:"node_id"`
 	State  string `json:"state"`
 	Title  string `json:"title"`
 	Body   string `json:"body"`
@@ -650,8 +1301,11 @@ type Issue struct {
 	Base        *PullRequestSpec `json:"base"`
 
 	MergeCommitSha      string `json:"merge_commit_sha"`
+	Mergeable           *bool  `json:"mergeable"`
+	MergeableState      string `json:"mergeable_state"`
 	MaintainerCanModify bool   `json:"maintainer_can_modify"`
 	Draft               bool   `json:"draft"`
+	Commits             int    `json:"commits"`
 
 	Comments  int          `json:"comments"`
 	Labels    []IssueLabel `json:"labels"`
@@ -704,8 +1358,9 @@ func (pr *PullRequest) HasRequestedTeam(name string) bool {
 }
 
 type IssueLabel struct {
-	Name  string `json:"name"`
-	Color string `json:"color"`
+	Name        string `json:"name"`
+	Color       string `json:"color"`
+	Description string `json:"description"`
 }
 
 type User struct {
@@ -718,8 +1373,16 @@ type Team struct {
 }
 
 type Milestone struct {
-	Number int    `json:"number"`
-	Title  string `json:"title"`
+	Number       int       `json:"number"`
+	Title        string    `json:"title"`
+	Description  string    `json:"description"`
+	State        string    `json:"state"`
+	HTMLURL      string    `json:"html_url"`
+	OpenIssues   int       `json:"open_issues"`
+	ClosedIssues int       `json:"closed_issues"`
+	DueOn        time.Time `json:"due_on"`
+	CreatedAt    time.Time `json:"created_at"`
+	ClosedAt     time.Time `json:"closed_at"`
 }
 
 func (client *Client) FetchIssues(project *Project, filterParams map[string]interface{}, limit int, filter func(*Issue) bool) (issues []Issue, err error) {
@@ -761,6 +1424,54 @@ func (client *Client) FetchIssues(project *Project, filterParams map[string]inte
 	return
 }
 
+type IssueSearchResult struct {
+	TotalCount        int     `json:"total_count"`
+	IncompleteResults bool    `json:"incomplete_results"`
+	Items             []Issue `json:"items"`
+}
+
+func (client *Client) SearchIssues(query string, params map[string]interface{}, limit int, filter func(*Issue) bool) (issues []Issue, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	searchParams := map[string]interface{}{
+		"q": query,
+	}
+	for key, value := range params {
+		searchParams[key] = value
+	}
+	path := addQuery(fmt.Sprintf("search/issues?per_page=%d", perPage(limit, 100)), searchParams)
+
+	issues = []Issue{}
+	var res *simpleResponse
+
+	for path != "" {
+		res, err = api.Get(path)
+		if err = checkStatus(200, "searching issues", res, err); err != nil {
+			return
+		}
+		path = res.Link("next")
+
+		result := IssueSearchResult{}
+		if err = res.Unmarshal(&result); err != nil {
+			return
+		}
+		for _, issue := range result.Items {
+			if filter == nil || filter(&issue) {
+				issues = append(issues, issue)
+				if limit > 0 && len(issues) == limit {
+					path = ""
+					break
+				}
+			}
+		}
+	}
+
+	return
+}
+
 func (client *Client) FetchIssue(project *Project, number string) (issue *Issue, err error) {
 	api, err := client.simpleAPI()
 	if err != nil {
@@ -783,16 +1494,75 @@ func (client *Client) FetchComments(project *Project, number string) (comments [
 		return
 	}
 
-	res, err := api.Get(fmt.Sprintf("repos/%s/%s/issues/%s/comments", project.Owner, project.Name, number))
-	if err = checkStatus(200, "fetching comments for issue", res, err); err != nil {
-		return nil, err
+	path := fmt.Sprintf("repos/%s/%s/issues/%s/comments?per_page=100", project.Owner, project.Name, number)
+	comments = []Comment{}
+	var res *simpleResponse
+
+	for path != "" {
+		res, err = api.Get(path)
+		if err = checkStatus(200, "fetching comments for issue", res, err); err != nil {
+			return nil, err
+		}
+		path = res.Link("next")
+
+		commentsPage := []Comment{}
+		if err = res.Unmarshal(&commentsPage); err != nil {
+			return
+		}
+		comments = append(comments, commentsPage...)
 	}
 
-	comments = []Comment{}
-	err = res.Unmarshal(&comments)
 	return
 }
 
+func (client *Client) CreateIssueComment(project *Project, issueNumber int, body string) (comment *Comment, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	params := map[string]interface{}{
+		"body": body,
+	}
+	res, err := api.PostJSON(fmt.Sprintf("repos/%s/%s/issues/%d/comments", project.Owner, project.Name, issueNumber), params)
+	if err = checkStatus(201, "creating comment", res, err); err != nil {
+		return
+	}
+
+	comment = &Comment{}
+	err = res.Unmarshal(comment)
+	return
+}
+
+func (client *Client) UpdateIssueComment(project *Project, commentID int, body string) (comment *Comment, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	params := map[string]interface{}{
+		"body": body,
+	}
+	res, err := api.PatchJSON(fmt.Sprintf("repos/%s/%s/issues/comments/%d", project.Owner, project.Name, commentID), params)
+	if err = checkStatus(200, "updating comment", res, err); err != nil {
+		return
+	}
+
+	comment = &Comment{}
+	err = res.Unmarshal(comment)
+	return
+}
+
+func (client *Client) DeleteIssueComment(project *Project, commentID int) (err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	res, err := api.Delete(fmt.Sprintf("repos/%s/%s/issues/comments/%d", project.Owner, project.Name, commentID))
+	return checkStatus(204, "deleting comment", res, err)
+}
+
 func (client *Client) CreateIssue(project *Project, params interface{}) (issue *Issue, err error) {
 	api, err := client.simpleAPI()
 	if err != nil {
@@ -866,13 +1636,60 @@ func (client *Client) FetchLabels(project *Project) (labels []IssueLabel, err er
 	return
 }
 
-func (client *Client) FetchMilestones(project *Project) (milestones []Milestone, err error) {
+func (client *Client) CreateLabel(project *Project, params map[string]interface{}) (label *IssueLabel, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	res, err := api.PostJSON(fmt.Sprintf("repos/%s/%s/labels", project.Owner, project.Name), params)
+	if err = checkStatus(201, "creating label", res, err); err != nil {
+		return
+	}
+
+	label = &IssueLabel{}
+	err = res.Unmarshal(label)
+	return
+}
+
+func (client *Client) UpdateLabel(project *Project, name string, params map[string]interface{}) (label *IssueLabel, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	res, err := api.PatchJSON(fmt.Sprintf("repos/%s/%s/labels/%s", project.Owner, project.Name, url.PathEscape(name)), params)
+	if err = checkStatus(200, "updating label", res, err); err != nil {
+		return
+	}
+
+	label = &IssueLabel{}
+	err = res.Unmarshal(label)
+	return
+}
+
+func (client *Client) DeleteLabel(project *Project, name string) (err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	res, err := api.Delete(fmt.Sprintf("repos/%s/%s/labels/%s", project.Owner, project.Name, url.PathEscape(name)))
+	err = checkStatus(204, "deleting label", res, err)
+
+	return
+}
+
+func (client *Client) FetchMilestones(project *Project, filterParams map[string]interface{}) (milestones []Milestone, err error) {
 	api, err := client.simpleAPI()
 	if err != nil {
 		return
 	}
 
 	path := fmt.Sprintf("repos/%s/%s/milestones?per_page=100", project.Owner, project.Name)
+	if filterParams != nil {
+		path = addQuery(path, filterParams)
+	}
 
 	milestones = []Milestone{}
 	var res *simpleResponse
@@ -894,6 +1711,50 @@ func (client *Client) FetchMilestones(project *Project) (milestones []Milestone,
 	return
 }
 
+func (client *Client) CreateMilestone(project *Project, params map[string]interface{}) (milestone *Milestone, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	res, err := api.PostJSON(fmt.Sprintf("repos/%s/%s/milestones", project.Owner, project.Name), params)
+	if err = checkStatus(201, "creating milestone", res, err); err != nil {
+		return
+	}
+
+	milestone = &Milestone{}
+	err = res.Unmarshal(milestone)
+	return
+}
+
+func (client *Client) UpdateMilestone(project *Project, milestoneNumber int, params map[string]interface{}) (milestone *Milestone, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	res, err := api.PatchJSON(fmt.Sprintf("repos/%s/%s/milestones/%d", project.Owner, project.Name, milestoneNumber), params)
+	if err = checkStatus(200, "updating milestone", res, err); err != nil {
+		return
+	}
+
+	milestone = &Milestone{}
+	err = res.Unmarshal(milestone)
+	return
+}
+
+func (client *Client) DeleteMilestone(project *Project, milestoneNumber int) (err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	res, err := api.Delete(fmt.Sprintf("repos/%s/%s/milestones/%d", project.Owner, project.Name, milestoneNumber))
+	err = checkStatus(204, "deleting milestone", res, err)
+
+	return
+}
+
 func (client *Client) GenericAPIRequest(method, path string, data interface{}, headers map[string]string, ttl int) (*simpleResponse, error) {
 	api, err := client.simpleAPI()
 	if err != nil {
@@ -1066,6 +1927,9 @@ func (client *Client) ensureAccessToken() error {
 }
 
 func (client *Client) simpleAPI() (c *simpleClient, err error) {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+
 	err = client.ensureAccessToken()
 	if err != nil {
 		return
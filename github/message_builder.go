@@ -19,7 +19,37 @@ func (b *MessageBuilder) AddCommentedSection(section string) {
 }
 
 func (b *MessageBuilder) Extract() (title, body string, err error) {
-	content := b.Message
+	content, err := b.content()
+	if err != nil {
+		return
+	}
+
+	title, body = SplitTitleBody(content)
+	if title == "" {
+		defer b.Cleanup()
+	}
+
+	return
+}
+
+// ExtractBody returns the whole message as a single Markdown body, without
+// treating the first block of text as a title
+func (b *MessageBuilder) ExtractBody() (body string, err error) {
+	content, err := b.content()
+	if err != nil {
+		return
+	}
+
+	body = strings.TrimSpace(content)
+	if body == "" {
+		defer b.Cleanup()
+	}
+
+	return
+}
+
+func (b *MessageBuilder) content() (content string, err error) {
+	content = b.Message
 
 	if b.Edit {
 		b.editor, err = NewEditor(b.Filename, b.Title, content)
@@ -30,19 +60,11 @@ func (b *MessageBuilder) Extract() (title, body string, err error) {
 			b.editor.AddCommentedSection(section)
 		}
 		content, err = b.editor.EditContent()
-		if err != nil {
-			return
-		}
 	} else {
 		nl := regexp.MustCompile(`\r?\n`)
 		content = nl.ReplaceAllString(content, "\n")
 	}
 
-	title, body = SplitTitleBody(content)
-	if title == "" {
-		defer b.Cleanup()
-	}
-
 	return
 }
 
@@ -21,3 +21,13 @@ description`,
 	assert.Equal(t, "hello multiline text", title)
 	assert.Equal(t, "the rest is\ndescription", body)
 }
+
+func TestMessageBuilder_ExtractBody(t *testing.T) {
+	builder := &MessageBuilder{
+		Message: "hello\r\nmultiline\r\ntext\r\n\r\nthe rest is\r\ndescription\r\n",
+	}
+
+	body, err := builder.ExtractBody()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "hello\nmultiline\ntext\n\nthe rest is\ndescription", body)
+}
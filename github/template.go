@@ -1,11 +1,14 @@
 package github
 
 import (
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 	"sort"
 	"strings"
+
+	"gopkg.in/yaml.v2"
 )
 
 const (
@@ -33,6 +36,140 @@ func ReadTemplate(kind, workdir string) (body string, err error) {
 	return
 }
 
+// Template is one of multiple named templates found in a directory such as
+// ".github/PULL_REQUEST_TEMPLATE/"
+type Template struct {
+	Name string
+	Path string
+}
+
+// ListTemplates finds the named templates of a kind, sorted by name
+func ListTemplates(kind, workdir string) (templates []Template, err error) {
+	templates = []Template{}
+
+	dir := ""
+	for _, parent := range []string{filepath.Join(workdir, githubTemplateDir), filepath.Join(workdir, docsDir), workdir} {
+		if dir, err = getDirPath(parent, kind); err != nil || dir != "" {
+			break
+		}
+	}
+	if err != nil || dir == "" {
+		return templates, nil
+	}
+
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return
+	}
+
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+		fileName := file.Name()
+		ext := strings.ToLower(filepath.Ext(fileName))
+		name := strings.TrimSuffix(fileName, filepath.Ext(fileName))
+		switch ext {
+		case ".yml", ".yaml":
+			// "config.yml" configures the issue template chooser on GitHub
+			if kind != IssueTemplate || strings.EqualFold(name, "config") {
+				continue
+			}
+		case ".md", ".txt":
+		default:
+			continue
+		}
+		templates = append(templates, Template{
+			Name: name,
+			Path: filepath.Join(dir, fileName),
+		})
+	}
+
+	sort.Slice(templates, func(i, j int) bool {
+		return strings.ToLower(templates[i].Name) < strings.ToLower(templates[j].Name)
+	})
+	return
+}
+
+// Read returns the contents of the template. YAML issue forms are rendered to
+// a Markdown skeleton of their fields.
+func (t Template) Read() (string, error) {
+	contents, err := readContentsFromFile(t.Path)
+	if err != nil {
+		return "", err
+	}
+
+	switch strings.ToLower(filepath.Ext(t.Path)) {
+	case ".yml", ".yaml":
+		return renderIssueForm(contents)
+	}
+	return contents, nil
+}
+
+type issueForm struct {
+	Name  string
+	Title string
+	Body  []struct {
+		Type       string
+		Attributes struct {
+			Label       string
+			Description string
+			Placeholder string
+			Value       string
+			Options     []interface{}
+		}
+	}
+}
+
+func renderIssueForm(contents string) (string, error) {
+	form := issueForm{}
+	if err := yaml.Unmarshal([]byte(contents), &form); err != nil {
+		return "", fmt.Errorf("error parsing issue form: %s", err)
+	}
+
+	sections := []string{form.Title}
+	for _, field := range form.Body {
+		attrs := field.Attributes
+		if field.Type == "markdown" || attrs.Label == "" {
+			continue
+		}
+
+		lines := []string{"### " + attrs.Label, ""}
+		hints := []string{}
+		if attrs.Description != "" {
+			hints = append(hints, strings.TrimSpace(attrs.Description))
+		}
+		if attrs.Placeholder != "" && attrs.Value == "" {
+			hints = append(hints, strings.TrimSpace(attrs.Placeholder))
+		}
+
+		switch field.Type {
+		case "dropdown":
+			options := []string{}
+			for _, option := range attrs.Options {
+				options = append(options, fmt.Sprint(option))
+			}
+			hints = append(hints, "Choose one of: "+strings.Join(options, ", "))
+		case "checkboxes":
+			for _, option := range attrs.Options {
+				if o, ok := option.(map[interface{}]interface{}); ok {
+					attrs.Value += fmt.Sprintf("- [ ] %v\n", o["label"])
+				}
+			}
+		}
+
+		if len(hints) > 0 {
+			lines = append(lines, fmt.Sprintf("<!-- %s -->", strings.Join(hints, " ")))
+		}
+		if value := strings.TrimSpace(attrs.Value); value != "" {
+			lines = append(lines, value)
+		}
+		sections = append(sections, strings.Join(lines, "\n"))
+	}
+
+	return strings.TrimLeft(strings.Join(sections, "\n\n"), "\n"), nil
+}
+
 type sortedFiles []os.FileInfo
 
 func (s sortedFiles) Len() int {
@@ -66,6 +203,24 @@ func getFilePath(dir, pattern string) (found string, err error) {
 	return
 }
 
+func getDirPath(dir, name string) (found string, err error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			err = nil
+		}
+		return
+	}
+
+	for _, file := range files {
+		if file.IsDir() && strings.EqualFold(file.Name(), name) {
+			found = filepath.Join(dir, file.Name())
+			return
+		}
+	}
+	return
+}
+
 func readContentsFromFile(filename string) (contents string, err error) {
 	content, err := ioutil.ReadFile(filename)
 	if err != nil {
@@ -136,6 +136,113 @@ func TestGithubTemplate_WithTemplateInDocsDir(t *testing.T) {
 	assert.Equal(t, issueContent, tpl)
 }
 
+func TestGithubTemplate_ListTemplates(t *testing.T) {
+	repo := fixtures.SetupTestRepo()
+	defer repo.TearDown()
+
+	repo.AddFile("test.git/.github/PULL_REQUEST_TEMPLATE/feature.md", prContent)
+	repo.AddFile("test.git/.github/PULL_REQUEST_TEMPLATE/Bugfix.md", "Fixes #")
+	repo.AddFile("test.git/.github/PULL_REQUEST_TEMPLATE/form.yml", "name: Form")
+	repo.AddFile("test.git/.github/ISSUE_TEMPLATE/bug.yml", "name: Bug")
+	repo.AddFile("test.git/.github/ISSUE_TEMPLATE/config.yml", "blank_issues_enabled: false")
+	repo.AddFile("test.git/.github/ISSUE_TEMPLATE/question.md", issueContent)
+
+	pwd, _ := os.Getwd()
+	templates, err := ListTemplates(PullRequestTemplate, pwd)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 2, len(templates))
+	assert.Equal(t, "Bugfix", templates[0].Name)
+	assert.Equal(t, "feature", templates[1].Name)
+
+	tpl, err := templates[1].Read()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, prContent, tpl)
+
+	templates, err = ListTemplates(IssueTemplate, pwd)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 2, len(templates))
+	assert.Equal(t, "bug", templates[0].Name)
+	assert.Equal(t, "question", templates[1].Name)
+}
+
+func TestGithubTemplate_ListTemplatesWithoutDir(t *testing.T) {
+	repo := fixtures.SetupTestRepo()
+	defer repo.TearDown()
+
+	addGithubTemplates(repo, map[string]string{"dir": githubTemplateDir})
+
+	pwd, _ := os.Getwd()
+	templates, err := ListTemplates(PullRequestTemplate, pwd)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 0, len(templates))
+}
+
+func TestGithubTemplate_IssueForm(t *testing.T) {
+	repo := fixtures.SetupTestRepo()
+	defer repo.TearDown()
+
+	repo.AddFile("test.git/.github/ISSUE_TEMPLATE/bug.yml", `name: Bug report
+description: File a bug report
+title: "[Bug]: "
+body:
+  - type: markdown
+    attributes:
+      value: Thanks for taking the time!
+  - type: input
+    id: version
+    attributes:
+      label: Version
+      placeholder: e.g. 2.14.2
+  - type: textarea
+    id: what-happened
+    attributes:
+      label: What happened?
+      description: Also tell us what you expected.
+      value: A bug happened!
+  - type: dropdown
+    id: os
+    attributes:
+      label: Operating system
+      options:
+        - macOS
+        - Linux
+  - type: checkboxes
+    id: terms
+    attributes:
+      label: Checklist
+      options:
+        - label: I searched existing issues
+        - label: I read the docs
+`)
+
+	pwd, _ := os.Getwd()
+	templates, err := ListTemplates(IssueTemplate, pwd)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, len(templates))
+
+	tpl, err := templates[0].Read()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, `[Bug]: 
+
+### Version
+
+<!-- e.g. 2.14.2 -->
+
+### What happened?
+
+<!-- Also tell us what you expected. -->
+A bug happened!
+
+### Operating system
+
+<!-- Choose one of: macOS, Linux -->
+
+### Checklist
+
+- [ ] I searched existing issues
+- [ ] I read the docs`, tpl)
+}
+
 func addGithubTemplates(r *fixtures.TestRepo, config map[string]string) {
 	repoDir := "test.git"
 	if dir := config["dir"]; dir != "" {
@@ -155,3 +262,14 @@ func addGithubTemplates(r *fixtures.TestRepo, config map[string]string) {
 	r.AddFile(prTemplatePath, prContent)
 	r.AddFile(issueTemplatePath, issueContent)
 }
+
+func TestGithubTemplate_IssueFormWithoutTitle(t *testing.T) {
+	tpl, err := renderIssueForm(`name: Question
+body:
+  - type: textarea
+    attributes:
+      label: Question
+`)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "### Question\n", tpl)
+}